@@ -0,0 +1,127 @@
+package keycloak
+
+import "context"
+
+// AccountService handles communication with the Keycloak account API on
+// behalf of the user whose token is provided
+type AccountService service
+
+// AccountProfile represents the profile of the logged-in user
+type AccountProfile struct {
+	Attributes    *map[string][]string `json:"attributes,omitempty"`
+	Email         *string              `json:"email,omitempty"`
+	EmailVerified *bool                `json:"emailVerified,omitempty"`
+	FirstName     *string              `json:"firstName,omitempty"`
+	ID            *string              `json:"id,omitempty"`
+	LastName      *string              `json:"lastName,omitempty"`
+	Username      *string              `json:"username,omitempty"`
+}
+
+// AccountSession represents a session of the logged-in user
+type AccountSession struct {
+	Browser    *string          `json:"browser,omitempty"`
+	Clients    *[]AccountClient `json:"clients,omitempty"`
+	Current    *bool            `json:"current,omitempty"`
+	Expires    *int64           `json:"expires,omitempty"`
+	ID         *string          `json:"id,omitempty"`
+	IPAddress  *string          `json:"ipAddress,omitempty"`
+	LastAccess *int64           `json:"lastAccess,omitempty"`
+	Started    *int64           `json:"started,omitempty"`
+}
+
+// AccountClient represents a client used within an account session
+type AccountClient struct {
+	ClientID            *string `json:"clientId,omitempty"`
+	ClientName          *string `json:"clientName,omitempty"`
+	UserConsentRequired *bool   `json:"userConsentRequired,omitempty"`
+}
+
+// GetProfile retrieves the profile of the user the token belongs to
+func (c *AccountService) GetProfile(
+	ctx context.Context,
+	token string,
+) (*AccountProfile, *Response, error) {
+	path, err := c.client.realmPath("account")
+	if err != nil {
+		return nil, nil, err
+	}
+	h := headers{authorization: token, accept: jsonContent}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, h, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	profile := new(AccountProfile)
+	resp, err := c.client.do(ctx, req, profile)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return profile, resp, nil
+}
+
+// UpdateProfile updates the profile of the user the token belongs to
+func (c *AccountService) UpdateProfile(
+	ctx context.Context,
+	token string,
+	profile *AccountProfile,
+) (*Response, error) {
+	path, err := c.client.realmPath("account")
+	if err != nil {
+		return nil, err
+	}
+	h := headers{authorization: token, accept: jsonContent}
+
+	req, err := c.client.newRequest(ctx, "POST", path, profile, h, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// GetSessions retrieves the sessions of the user the token belongs to
+func (c *AccountService) GetSessions(
+	ctx context.Context,
+	token string,
+) ([]*AccountSession, *Response, error) {
+	path, err := c.client.realmPath("account", "sessions")
+	if err != nil {
+		return nil, nil, err
+	}
+	h := headers{authorization: token, accept: jsonContent}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, h, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sessions []*AccountSession
+	resp, err := c.client.do(ctx, req, &sessions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sessions, resp, nil
+}
+
+// DeleteSession logs the user the token belongs to out of one session
+func (c *AccountService) DeleteSession(
+	ctx context.Context,
+	token string,
+	sessionID string,
+) (*Response, error) {
+	path, err := c.client.realmPath("account", "sessions", sessionID)
+	if err != nil {
+		return nil, err
+	}
+	h := headers{authorization: token, accept: jsonContent}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, nil, h, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
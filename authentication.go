@@ -3,38 +3,103 @@ package keycloak
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 )
 
 // AuthenticationService handles communication with Keyloak authentication
 type AuthenticationService service
 
+// GrantType identifies the OAuth 2.0 grant of a token request
+type GrantType string
+
+// Grant types supported by GetOIDCToken
+const (
+	GrantPassword          GrantType = "password"
+	GrantClientCredentials GrantType = "client_credentials"
+	GrantRefreshToken      GrantType = "refresh_token"
+	GrantAuthorizationCode GrantType = "authorization_code"
+	GrantTokenExchange     GrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	GrantDeviceCode        GrantType = "urn:ietf:params:oauth:grant-type:device_code"
+	GrantUMATicket         GrantType = "urn:ietf:params:oauth:grant-type:uma-ticket" // ticket, permission, etc. go in Extra
+)
+
+// Valid reports whether g is one of the supported grant types
+func (g GrantType) Valid() bool {
+	switch g {
+	case GrantPassword, GrantClientCredentials, GrantRefreshToken,
+		GrantAuthorizationCode, GrantTokenExchange, GrantDeviceCode,
+		GrantUMATicket:
+		return true
+	}
+	return false
+}
+
 // AccessGrantRequest represents a request for grant type authentication
 type AccessGrantRequest struct {
-	GrantType    string `url:"grant_type"`
-	Scope        string `url:"scope,omitempty"`
-	Username     string `url:"username,omitempty"`
-	Password     string `url:"password,omitempty"`
-	ClientID     string `url:"client_id"`
-	ClientSecret string `url:"client_secret,omitempty"`
+	GrantType    GrantType `url:"grant_type"`
+	Scope        string    `url:"scope,omitempty"`
+	Username     string    `url:"username,omitempty"`
+	Password     string    `url:"password,omitempty"`
+	RefreshToken string    `url:"refresh_token,omitempty"`
+	ClientID     string    `url:"client_id"`
+	ClientSecret string    `url:"client_secret,omitempty"`
+	Audience     []string  `url:"audience,omitempty"`   // sent as one audience param per value
+	AcrValues    string    `url:"acr_values,omitempty"` // space separated, e.g. for step-up authentication
+
+	// Extra holds additional form parameters. It is encoded last and
+	// cannot override the parameters set by the fields above.
+	Extra FormParams `url:"extra,omitempty"`
+}
+
+// FormParams represents arbitrary form parameters of a request
+type FormParams map[string]string
+
+// EncodeValues adds each parameter under its own name, skipping any that
+// are already set
+func (p FormParams) EncodeValues(_ string, v *url.Values) error {
+	for name, value := range p {
+		if _, ok := (*v)[name]; !ok {
+			v.Set(name, value)
+		}
+	}
+	return nil
 }
 
 // OIDCToken represents a credential token to access keycloak
 type OIDCToken struct {
 	AccessToken      string `json:"access_token"`
 	ExpiresIn        int    `json:"expires_in"`
+	IDToken          string `json:"id_token"` // only issued with the openid scope
 	RefreshExpiresIn int    `json:"refresh_expires_in"`
 	RefreshToken     string `json:"refresh_token"`
 	TokenType        string `json:"token_type"`
-	NotBeforePolicy  int    `json:"not_before_policy"`
+	NotBeforePolicy  int    `json:"not-before-policy"`
 	SessionState     string `json:"session_state"`
 	Scope            string `json:"scope"`
 }
 
-// GetOIDCToken authenticates the access grant request
+// AuthorizationHeader returns the Authorization header value for the
+// access token using its token type, defaulting to Bearer
+func (t *OIDCToken) AuthorizationHeader() string {
+	tokenType := t.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+	return tokenType + " " + t.AccessToken
+}
+
+// GetOIDCToken authenticates the access grant request. An unsupported
+// grant type is rejected before the request is sent.
 func (c *AuthenticationService) GetOIDCToken(
 	ctx context.Context,
 	grantReq *AccessGrantRequest,
 ) (*OIDCToken, *Response, error) {
+	if !grantReq.GrantType.Valid() {
+		return nil, nil, fmt.Errorf("keycloak: unsupported grant type %q", grantReq.GrantType)
+	}
+
 	// Use client configured credentials
 	if grantReq.ClientID == "" {
 		grantReq.ClientID = c.client.clientID
@@ -43,10 +108,13 @@ func (c *AuthenticationService) GetOIDCToken(
 		grantReq.ClientSecret = c.client.clientSecret
 	}
 
-	path := fmt.Sprintf("%s/%s/protocol/openid-connect/token", defaultBase, c.client.realm)
+	path, err := c.client.tokenPath()
+	if err != nil {
+		return nil, nil, err
+	}
 	h := headers{contentType: formEncoded}
 
-	req, err := c.client.newRequest("POST", path, grantReq, h, false)
+	req, err := c.client.newRequest(ctx, "POST", path, grantReq, h, false)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -59,3 +127,170 @@ func (c *AuthenticationService) GetOIDCToken(
 
 	return token, resp, nil
 }
+
+// GetOIDCTokenForm requests a token with a pre-built form, for grants
+// whose parameters AccessGrantRequest cannot express, such as repeated
+// scope values. The configured client_id and client_secret are added
+// unless form sets them. The grant type is not validated. form is not
+// modified.
+func (c *AuthenticationService) GetOIDCTokenForm(
+	ctx context.Context,
+	form url.Values,
+) (*OIDCToken, *Response, error) {
+	values := make(url.Values, len(form)+2)
+	for name, v := range form {
+		values[name] = append([]string(nil), v...)
+	}
+	if values.Get("client_id") == "" {
+		values.Set("client_id", c.client.clientID)
+	}
+	if c.client.isConfidential && values.Get("client_secret") == "" {
+		values.Set("client_secret", c.client.clientSecret)
+	}
+
+	path, err := c.client.tokenPath()
+	if err != nil {
+		return nil, nil, err
+	}
+	h := headers{contentType: formEncoded}
+
+	req, err := c.client.newRequest(ctx, "POST", path, values, h, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := new(OIDCToken)
+	resp, err := c.client.do(ctx, req, token)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return token, resp, nil
+}
+
+// TestClientCredentials reports whether the configured client ID and
+// secret are accepted by a client_credentials grant. The issued token is
+// discarded. Only an invalid_client or unauthorized_client rejection
+// reports false; any other failure is returned as an error.
+func (c *Client) TestClientCredentials(ctx context.Context) (bool, *Response, error) {
+	_, resp, err := c.Authentication.GetOIDCToken(ctx, &AccessGrantRequest{
+		GrantType: GrantClientCredentials,
+	})
+	if err != nil {
+		if errResp, ok := err.(*ErrorResponse); ok {
+			switch errResp.ErrorCode {
+			case "invalid_client", "unauthorized_client":
+				return false, &Response{Response: errResp.Response}, nil
+			}
+		}
+		return false, resp, err
+	}
+
+	return true, resp, nil
+}
+
+// logoutRequest represents a request ending the session of a refresh token
+type logoutRequest struct {
+	RefreshToken string `url:"refresh_token"`
+	ClientID     string `url:"client_id"`
+	ClientSecret string `url:"client_secret,omitempty"`
+}
+
+// Logout ends the user session the refresh token belongs to, which must
+// have been issued to the configured client
+func (c *AuthenticationService) Logout(
+	ctx context.Context,
+	refreshToken string,
+) (*Response, error) {
+	path, err := c.client.realmPath("protocol", "openid-connect", "logout")
+	if err != nil {
+		return nil, err
+	}
+	h := headers{contentType: formEncoded}
+
+	body := &logoutRequest{
+		RefreshToken: refreshToken,
+		ClientID:     c.client.clientID,
+	}
+	if c.client.isConfidential {
+		body.ClientSecret = c.client.clientSecret
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, body, h, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// introspectRequest represents a token introspection request
+type introspectRequest struct {
+	Token        string `url:"token"`
+	ClientID     string `url:"client_id"`
+	ClientSecret string `url:"client_secret,omitempty"`
+}
+
+// TokenInfo represents the state of an access token
+type TokenInfo struct {
+	// Active reports whether Keycloak still accepts the token. A token
+	// is inactive once it expires or its session ends.
+	Active bool
+
+	Subject   string
+	ExpiresAt time.Time
+	ExpiresIn time.Duration // remaining lifetime, zero once expired
+
+	Claims *TokenClaims
+}
+
+// TokenInfo decodes accessToken and introspects it with the configured
+// client, which must be confidential, to report whether it is active and
+// how long it remains valid
+func (c *AuthenticationService) TokenInfo(
+	ctx context.Context,
+	accessToken string,
+) (*TokenInfo, *Response, error) {
+	claims, err := DecodeToken(accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path, err := c.client.realmPath("protocol", "openid-connect", "token", "introspect")
+	if err != nil {
+		return nil, nil, err
+	}
+	h := headers{contentType: formEncoded}
+
+	body := &introspectRequest{
+		Token:        accessToken,
+		ClientID:     c.client.clientID,
+		ClientSecret: c.client.clientSecret,
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, body, h, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Introspection has no side effects so it is always retryable
+	var introspection struct {
+		Active bool `json:"active"`
+	}
+	resp, err := c.client.do(WithRetryablePOST(ctx), req, &introspection)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	info := &TokenInfo{
+		Active:    introspection.Active,
+		Subject:   claims.Subject,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+		Claims:    claims,
+	}
+	if remaining := time.Until(info.ExpiresAt); remaining > 0 {
+		info.ExpiresIn = remaining
+	}
+
+	return info, resp, nil
+}
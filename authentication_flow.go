@@ -0,0 +1,116 @@
+package keycloak
+
+import "context"
+
+// AuthenticationFlowService handles communication with keycloak
+// authentication flow management
+type AuthenticationFlowService service
+
+// AuthenticationFlow represents an authentication flow
+type AuthenticationFlow struct {
+	Alias                    *string                          `json:"alias,omitempty"`
+	AuthenticationExecutions *[]AuthenticationExecutionExport `json:"authenticationExecutions,omitempty"`
+	BuiltIn                  *bool                            `json:"builtIn,omitempty"`
+	Description              *string                          `json:"description,omitempty"`
+	ID                       *string                          `json:"id,omitempty"`
+	ProviderID               *string                          `json:"providerId,omitempty"`
+	TopLevel                 *bool                            `json:"topLevel,omitempty"`
+}
+
+// AuthenticationExecutionExport represents an execution as nested in a flow
+type AuthenticationExecutionExport struct {
+	Authenticator       *string `json:"authenticator,omitempty"`
+	AuthenticatorConfig *string `json:"authenticatorConfig,omitempty"`
+	AuthenticatorFlow   *bool   `json:"authenticatorFlow,omitempty"`
+	FlowAlias           *string `json:"flowAlias,omitempty"`
+	Priority            *int32  `json:"priority,omitempty"`
+	Requirement         *string `json:"requirement,omitempty"`
+	UserSetupAllowed    *bool   `json:"userSetupAllowed,omitempty"`
+}
+
+// AuthenticationExecution represents an execution of a flow and its
+// requirement, e.g. REQUIRED, ALTERNATIVE, CONDITIONAL, or DISABLED
+type AuthenticationExecution struct {
+	Alias                *string   `json:"alias,omitempty"`
+	AuthenticationConfig *string   `json:"authenticationConfig,omitempty"`
+	AuthenticationFlow   *bool     `json:"authenticationFlow,omitempty"`
+	Configurable         *bool     `json:"configurable,omitempty"`
+	Description          *string   `json:"description,omitempty"`
+	DisplayName          *string   `json:"displayName,omitempty"`
+	FlowID               *string   `json:"flowId,omitempty"`
+	ID                   *string   `json:"id,omitempty"`
+	Index                *int32    `json:"index,omitempty"`
+	Level                *int32    `json:"level,omitempty"`
+	Priority             *int32    `json:"priority,omitempty"`
+	ProviderID           *string   `json:"providerId,omitempty"`
+	Requirement          *string   `json:"requirement,omitempty"`
+	RequirementChoices   *[]string `json:"requirementChoices,omitempty"`
+}
+
+// GetFlows retrieves the realm's top level authentication flows
+func (c *AuthenticationFlowService) GetFlows(
+	ctx context.Context,
+) ([]*AuthenticationFlow, *Response, error) {
+	path, err := c.client.adminPath(ctx, "authentication", "flows")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var flows []*AuthenticationFlow
+	resp, err := c.client.do(ctx, req, &flows)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return flows, resp, nil
+}
+
+// GetExecutions retrieves the executions of the flow with the given alias,
+// including those of its subflows
+func (c *AuthenticationFlowService) GetExecutions(
+	ctx context.Context,
+	flowAlias string,
+) ([]*AuthenticationExecution, *Response, error) {
+	path, err := c.client.adminPath(ctx, "authentication", "flows", flowAlias, "executions")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var executions []*AuthenticationExecution
+	resp, err := c.client.do(ctx, req, &executions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return executions, resp, nil
+}
+
+// UpdateExecution updates an execution of the flow with the given alias.
+// Only the requirement and priority of an execution can be changed.
+func (c *AuthenticationFlowService) UpdateExecution(
+	ctx context.Context,
+	flowAlias string,
+	execution *AuthenticationExecution,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "authentication", "flows", flowAlias, "executions")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "PUT", path, execution, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
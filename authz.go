@@ -0,0 +1,301 @@
+package keycloak
+
+import "context"
+
+// ResourceServer represents the authorization settings of a client
+type ResourceServer struct {
+	AllowRemoteResourceManagement *bool            `json:"allowRemoteResourceManagement,omitempty"`
+	ClientID                      *string          `json:"clientId,omitempty"`
+	DecisionStrategy              *string          `json:"decisionStrategy,omitempty"`
+	ID                            *string          `json:"id,omitempty"`
+	Name                          *string          `json:"name,omitempty"`
+	Policies                      *[]AuthzPolicy   `json:"policies,omitempty"`
+	PolicyEnforcementMode         *string          `json:"policyEnforcementMode,omitempty"`
+	Resources                     *[]AuthzResource `json:"resources,omitempty"`
+	Scopes                        *[]AuthzScope    `json:"scopes,omitempty"`
+}
+
+// AuthzResource represents a resource protected by a resource server
+type AuthzResource struct {
+	Attributes         *map[string][]string `json:"attributes,omitempty"`
+	DisplayName        *string              `json:"displayName,omitempty"`
+	IconURI            *string              `json:"icon_uri,omitempty"`
+	ID                 *string              `json:"_id,omitempty"`
+	Name               *string              `json:"name,omitempty"`
+	OwnerManagedAccess *bool                `json:"ownerManagedAccess,omitempty"`
+	Scopes             *[]AuthzScope        `json:"scopes,omitempty"`
+	Type               *string              `json:"type,omitempty"`
+	URIs               *[]string            `json:"uris,omitempty"`
+}
+
+// AuthzScope represents a scope that can be granted on a resource
+type AuthzScope struct {
+	DisplayName *string `json:"displayName,omitempty"`
+	IconURI     *string `json:"iconUri,omitempty"`
+	ID          *string `json:"id,omitempty"`
+	Name        *string `json:"name,omitempty"`
+}
+
+// AuthzPolicy represents a policy or permission of a resource server.
+// Which of the typed fields apply depends on Type: Roles for role
+// policies, Users for user policies, and Resources, Scopes, and Policies
+// for resource and scope permissions.
+type AuthzPolicy struct {
+	Config           *map[string]string `json:"config,omitempty"`
+	DecisionStrategy *string            `json:"decisionStrategy,omitempty"`
+	Description      *string            `json:"description,omitempty"`
+	ID               *string            `json:"id,omitempty"`
+	Logic            *string            `json:"logic,omitempty"`
+	Name             *string            `json:"name,omitempty"`
+	Type             *string            `json:"type,omitempty"`
+
+	Policies     *[]string          `json:"policies,omitempty"`  // policy IDs or names a permission applies
+	Resources    *[]string          `json:"resources,omitempty"` // resource IDs or names a permission protects
+	ResourceType *string            `json:"resourceType,omitempty"`
+	Roles        *[]AuthzPolicyRole `json:"roles,omitempty"`
+	Scopes       *[]string          `json:"scopes,omitempty"` // scope IDs or names a permission protects
+	Users        *[]string          `json:"users,omitempty"`  // user IDs granted by a user policy
+}
+
+// AuthzPolicyRole represents a role granted by a role policy
+type AuthzPolicyRole struct {
+	ID       *string `json:"id,omitempty"` // role ID or name
+	Required *bool   `json:"required,omitempty"`
+}
+
+// Policy and permission types of a resource server
+const (
+	AuthzPolicyRoleType         = "role"
+	AuthzPolicyUserType         = "user"
+	AuthzPermissionResourceType = "resource"
+	AuthzPermissionScopeType    = "scope"
+)
+
+// GetPoliciesParams represents the optional search parameters of
+// GetPolicies
+type GetPoliciesParams struct {
+	First      int    `url:"first,omitempty"`
+	Max        int    `url:"max,omitempty"`
+	Name       string `url:"name,omitempty"`
+	Permission *bool  `url:"permission,omitempty"` // only permissions when true, only policies when false
+	Type       string `url:"type,omitempty"`
+}
+
+// GetPolicies retrieves the policies and permissions of the resource
+// server of the client with the given internal client UUID
+func (c *ClientService) GetPolicies(
+	ctx context.Context,
+	clientUUID string,
+	params *GetPoliciesParams,
+) ([]*AuthzPolicy, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "authz", "resource-server", "policy")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var policies []*AuthzPolicy
+	resp, err := c.client.do(ctx, req, &policies)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return policies, resp, nil
+}
+
+// CreatePolicy creates a policy of the given type, e.g. AuthzPolicyRoleType
+// or AuthzPolicyUserType, on the resource server of the client with the
+// given internal client UUID and returns it with its assigned ID
+func (c *ClientService) CreatePolicy(
+	ctx context.Context,
+	clientUUID string,
+	policyType string,
+	policy *AuthzPolicy,
+) (*AuthzPolicy, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "authz", "resource-server", "policy", policyType)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.createPolicy(ctx, path, policy)
+}
+
+// CreatePermission creates a permission of the given type, e.g.
+// AuthzPermissionScopeType, on the resource server of the client with the
+// given internal client UUID and returns it with its assigned ID. The
+// permission grants access to its resources and scopes when its policies
+// permit.
+func (c *ClientService) CreatePermission(
+	ctx context.Context,
+	clientUUID string,
+	permissionType string,
+	permission *AuthzPolicy,
+) (*AuthzPolicy, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "authz", "resource-server", "permission", permissionType)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.createPolicy(ctx, path, permission)
+}
+
+// createPolicy posts a policy or permission to path
+func (c *ClientService) createPolicy(
+	ctx context.Context,
+	path string,
+	policy *AuthzPolicy,
+) (*AuthzPolicy, *Response, error) {
+	req, err := c.client.newRequest(ctx, "POST", path, policy, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := new(AuthzPolicy)
+	resp, err := c.client.do(ctx, req, created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return created, resp, nil
+}
+
+// CreateScope creates a scope on the resource server of the client with
+// the given internal client UUID and returns it with its assigned ID
+func (c *ClientService) CreateScope(
+	ctx context.Context,
+	clientUUID string,
+	scope *AuthzScope,
+) (*AuthzScope, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "authz", "resource-server", "scope")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, scope, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := new(AuthzScope)
+	resp, err := c.client.do(ctx, req, created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return created, resp, nil
+}
+
+// GetScopes retrieves the scopes defined by the resource server of the
+// client with the given internal client UUID
+func (c *ClientService) GetScopes(
+	ctx context.Context,
+	clientUUID string,
+) ([]*AuthzScope, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "authz", "resource-server", "scope")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var scopes []*AuthzScope
+	resp, err := c.client.do(ctx, req, &scopes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return scopes, resp, nil
+}
+
+// DeleteScope deletes a scope from the resource server of the client with
+// the given internal client UUID
+func (c *ClientService) DeleteScope(
+	ctx context.Context,
+	clientUUID string,
+	scopeID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "authz", "resource-server", "scope", scopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// PolicyEvaluationRequest represents a hypothetical authorization request
+// to evaluate the policies of a resource server against
+type PolicyEvaluationRequest struct {
+	ClientID     *string                       `json:"clientId,omitempty"`
+	Context      *map[string]map[string]string `json:"context,omitempty"` // e.g. {"attributes": {"kc.client.network.ip_address": "127.0.0.1"}}
+	Entitlements *bool                         `json:"entitlements,omitempty"`
+	Resources    *[]AuthzResource              `json:"resources,omitempty"` // all resources when empty
+	RoleIDs      *[]string                     `json:"roleIds,omitempty"`
+	UserID       *string                       `json:"userId,omitempty"`
+}
+
+// PolicyEvaluationResponse represents the decision of a policy evaluation
+type PolicyEvaluationResponse struct {
+	Entitlements *bool                     `json:"entitlements,omitempty"`
+	Results      *[]PolicyEvaluationResult `json:"results,omitempty"`
+	Status       *string                   `json:"status,omitempty"` // PERMIT or DENY
+}
+
+// PolicyEvaluationResult represents the decision for a single resource
+type PolicyEvaluationResult struct {
+	AllowedScopes *[]AuthzScope   `json:"allowedScopes,omitempty"`
+	Policies      *[]PolicyResult `json:"policies,omitempty"`
+	Resource      *AuthzResource  `json:"resource,omitempty"`
+	Scopes        *[]AuthzScope   `json:"scopes,omitempty"`
+	Status        *string         `json:"status,omitempty"`
+}
+
+// PolicyResult represents the outcome of a permission and the policies
+// it applied
+type PolicyResult struct {
+	AssociatedPolicies *[]PolicyResult `json:"associatedPolicies,omitempty"`
+	Policy             *AuthzPolicy    `json:"policy,omitempty"`
+	Scopes             *[]string       `json:"scopes,omitempty"`
+	Status             *string         `json:"status,omitempty"`
+}
+
+// EvaluatePolicies evaluates the policies of the resource server of the
+// client with the given internal client UUID against evalReq and returns
+// the decision along with the permissions that produced it
+func (c *ClientService) EvaluatePolicies(
+	ctx context.Context,
+	clientUUID string,
+	evalReq *PolicyEvaluationRequest,
+) (*PolicyEvaluationResponse, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "authz", "resource-server", "policy", "evaluate")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, evalReq, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Evaluation has no side effects so it is always retryable
+	evaluation := new(PolicyEvaluationResponse)
+	resp, err := c.client.do(WithRetryablePOST(ctx), req, evaluation)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return evaluation, resp, nil
+}
@@ -0,0 +1,426 @@
+package keycloak
+
+import (
+	"bytes"
+	"context"
+	"errors"
+)
+
+// ClientService handles communication with keycloak client management
+type ClientService service
+
+// ErrClientNotFound is returned when a lookup matches no client
+var ErrClientNotFound = errors.New("keycloak: client not found")
+
+// ClientRepresentation represents a Keycloak client
+type ClientRepresentation struct {
+	Attributes                   *map[string]string `json:"attributes,omitempty"`
+	AuthorizationServicesEnabled *bool              `json:"authorizationServicesEnabled,omitempty"`
+	BaseURL                      *string            `json:"baseUrl,omitempty"`
+	BearerOnly                   *bool              `json:"bearerOnly,omitempty"`
+	ClientAuthenticatorType      *string            `json:"clientAuthenticatorType,omitempty"`
+	ClientID                     *string            `json:"clientId,omitempty"`
+	ConsentRequired              *bool              `json:"consentRequired,omitempty"`
+	DefaultClientScopes          *[]string          `json:"defaultClientScopes,omitempty"`
+	Description                  *string            `json:"description,omitempty"`
+	DirectAccessGrantsEnabled    *bool              `json:"directAccessGrantsEnabled,omitempty"`
+	Enabled                      *bool              `json:"enabled,omitempty"`
+	FullScopeAllowed             *bool              `json:"fullScopeAllowed,omitempty"`
+	ID                           *string            `json:"id,omitempty"`
+	ImplicitFlowEnabled          *bool              `json:"implicitFlowEnabled,omitempty"`
+	Name                         *string            `json:"name,omitempty"`
+	OptionalClientScopes         *[]string          `json:"optionalClientScopes,omitempty"`
+	Protocol                     *string            `json:"protocol,omitempty"`
+	PublicClient                 *bool              `json:"publicClient,omitempty"`
+	RedirectURIs                 *[]string          `json:"redirectUris,omitempty"`
+	RootURL                      *string            `json:"rootUrl,omitempty"`
+	Secret                       *string            `json:"secret,omitempty"`
+	ServiceAccountsEnabled       *bool              `json:"serviceAccountsEnabled,omitempty"`
+	StandardFlowEnabled          *bool              `json:"standardFlowEnabled,omitempty"`
+	WebOrigins                   *[]string          `json:"webOrigins,omitempty"`
+}
+
+// GetClientsParams represents the optional search parameters of GetClients
+type GetClientsParams struct {
+	ClientID     string `url:"clientId,omitempty"` // exact clientId match unless Search is set
+	First        int    `url:"first,omitempty"`
+	Max          int    `url:"max,omitempty"`
+	Search       bool   `url:"search,omitempty"` // substring clientId match
+	ViewableOnly bool   `url:"viewableOnly,omitempty"`
+}
+
+// GetClients retrieves the clients matching params
+func (c *ClientService) GetClients(
+	ctx context.Context,
+	params *GetClientsParams,
+) ([]*ClientRepresentation, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var clients []*ClientRepresentation
+	resp, err := c.client.do(ctx, req, &clients)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return clients, resp, nil
+}
+
+// GetClientUUID returns the internal UUID of the client with the given
+// clientId. ErrClientNotFound is returned when no client matches.
+func (c *ClientService) GetClientUUID(
+	ctx context.Context,
+	clientID string,
+) (string, *Response, error) {
+	clients, resp, err := c.GetClients(ctx, &GetClientsParams{ClientID: clientID})
+	if err != nil {
+		return "", resp, err
+	}
+	if len(clients) == 0 || clients[0].ID == nil {
+		return "", resp, ErrClientNotFound
+	}
+
+	return *clients[0].ID, resp, nil
+}
+
+// CountClients returns the number of clients matching params. Keycloak
+// has no count endpoint for clients, so the matching clients are listed
+// and only their IDs are decoded.
+func (c *ClientService) CountClients(
+	ctx context.Context,
+	params *GetClientsParams,
+) (int, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients")
+	if err != nil {
+		return 0, nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var ids []struct {
+		ID string `json:"id"`
+	}
+	resp, err := c.client.do(ctx, req, &ids)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return len(ids), resp, nil
+}
+
+// SetEnabled enables or disables the client with the given internal client
+// UUID, leaving the client's other fields untouched. A disabled client
+// cannot obtain tokens.
+func (c *ClientService) SetEnabled(
+	ctx context.Context,
+	clientUUID string,
+	enabled bool,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "PUT", path, &ClientRepresentation{Enabled: Bool(enabled)}, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// GetAuthorizationSettings retrieves the authorization settings of the
+// client with the given internal client UUID, including its resources,
+// scopes, and policies
+func (c *ClientService) GetAuthorizationSettings(
+	ctx context.Context,
+	clientUUID string,
+) (*ResourceServer, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "authz", "resource-server", "settings")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settings := new(ResourceServer)
+	resp, err := c.client.do(ctx, req, settings)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return settings, resp, nil
+}
+
+// GetInstallationProvider retrieves the adapter configuration generated
+// for the client by the given provider, e.g. keycloak-oidc-keycloak-json
+// for a keycloak.json file. The configuration is returned as is.
+func (c *ClientService) GetInstallationProvider(
+	ctx context.Context,
+	clientUUID string,
+	providerID string,
+) ([]byte, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "installation", "providers", providerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	resp, err := c.client.do(ctx, req, buf)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return buf.Bytes(), resp, nil
+}
+
+// evaluateScopesParams represents the query of the evaluate-scopes endpoints
+type evaluateScopesParams struct {
+	Scope  string `url:"scope,omitempty"`
+	UserID string `url:"userId,omitempty"`
+}
+
+// EvaluateGeneratedAccessToken previews the claims of the access token the
+// user would receive from the client with the given internal client UUID
+// when requesting scope, a space separated list of optional client scopes.
+// The claims are returned as a map so custom mapper claims are included.
+func (c *ClientService) EvaluateGeneratedAccessToken(
+	ctx context.Context,
+	clientUUID string,
+	userID string,
+	scope string,
+) (map[string]interface{}, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "evaluate-scopes", "generate-example-access-token")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, &evaluateScopesParams{Scope: scope, UserID: userID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claims := make(map[string]interface{})
+	resp, err := c.client.do(ctx, req, &claims)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return claims, resp, nil
+}
+
+// ProtocolMapperEvaluation represents a protocol mapper that applies to a
+// client for an evaluated scope, along with the client or client scope
+// that defines it
+type ProtocolMapperEvaluation struct {
+	ContainerID    *string `json:"containerId,omitempty"`
+	ContainerName  *string `json:"containerName,omitempty"`
+	ContainerType  *string `json:"containerType,omitempty"`
+	MapperID       *string `json:"mapperId,omitempty"`
+	MapperName     *string `json:"mapperName,omitempty"`
+	ProtocolMapper *string `json:"protocolMapper,omitempty"`
+}
+
+// GetEffectiveProtocolMappers retrieves the protocol mappers that apply to
+// tokens of the client with the given internal client UUID when
+// requesting scope, a space separated list of optional client scopes
+func (c *ClientService) GetEffectiveProtocolMappers(
+	ctx context.Context,
+	clientUUID string,
+	scope string,
+) ([]*ProtocolMapperEvaluation, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "evaluate-scopes", "protocol-mappers")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, &evaluateScopesParams{Scope: scope})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mappers []*ProtocolMapperEvaluation
+	resp, err := c.client.do(ctx, req, &mappers)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return mappers, resp, nil
+}
+
+// GetClientSessionsParams represents the optional paging parameters of
+// GetClientSessions
+type GetClientSessionsParams struct {
+	First int `url:"first,omitempty"`
+	Max   int `url:"max,omitempty"`
+}
+
+// GetClientSessions retrieves the active user sessions of the client with
+// the given internal client UUID, paged by params
+func (c *ClientService) GetClientSessions(
+	ctx context.Context,
+	clientUUID string,
+	params *GetClientSessionsParams,
+) ([]*UserSession, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "user-sessions")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sessions []*UserSession
+	resp, err := c.client.do(ctx, req, &sessions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sessions, resp, nil
+}
+
+// GetScopeMappings retrieves the realm roles the client with the given
+// internal client UUID may include in its tokens. Only relevant when the
+// client's FullScopeAllowed is off.
+func (c *ClientService) GetScopeMappings(
+	ctx context.Context,
+	clientUUID string,
+) ([]*Role, *Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "scope-mappings", "realm")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var roles []*Role
+	resp, err := c.client.do(ctx, req, &roles)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return roles, resp, nil
+}
+
+// AddScopeMappings allows the client with the given internal client UUID
+// to include realm roles in its tokens
+func (c *ClientService) AddScopeMappings(
+	ctx context.Context,
+	clientUUID string,
+	roles []*Role,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "scope-mappings", "realm")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, roles, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// RemoveScopeMappings stops the client with the given internal client
+// UUID from including realm roles in its tokens
+func (c *ClientService) RemoveScopeMappings(
+	ctx context.Context,
+	clientUUID string,
+	roles []*Role,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "scope-mappings", "realm")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, roles, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// ProtocolMapper represents a protocol mapper that adds a claim to the
+// tokens of a client or client scope
+type ProtocolMapper struct {
+	Config         *map[string]string `json:"config,omitempty"`
+	ID             *string            `json:"id,omitempty"`
+	Name           *string            `json:"name,omitempty"`
+	Protocol       *string            `json:"protocol,omitempty"`
+	ProtocolMapper *string            `json:"protocolMapper,omitempty"`
+}
+
+// UpdateProtocolMapper replaces the protocol mapper with the given ID on
+// the client with the given internal client UUID, keeping the mapper's ID
+func (c *ClientService) UpdateProtocolMapper(
+	ctx context.Context,
+	clientUUID string,
+	mapperID string,
+	mapper *ProtocolMapper,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "protocol-mappers", "models", mapperID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapper == nil {
+		return nil, errors.New("keycloak: protocol mapper is required")
+	}
+
+	body := *mapper
+	if body.ID == nil {
+		body.ID = &mapperID
+	}
+
+	req, err := c.client.newRequest(ctx, "PUT", path, &body, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
@@ -0,0 +1,62 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// DiagnosticStep identifies a step of Diagnose
+type DiagnosticStep string
+
+// Steps checked by Diagnose, in order
+const (
+	DiagnoseReachability DiagnosticStep = "reachability" // the base URL answers HTTP requests
+	DiagnoseRealm        DiagnosticStep = "realm"        // the realm exists under the base URL
+	DiagnoseCredentials  DiagnosticStep = "credentials"  // the configured credentials are granted a token
+)
+
+// Diagnosis represents the outcome of Diagnose
+type Diagnosis struct {
+	// FailedStep is the first step that failed, empty when all passed
+	FailedStep DiagnosticStep
+	Err        error
+}
+
+// OK reports whether every step passed
+func (d *Diagnosis) OK() bool { return d.FailedStep == "" }
+
+// Diagnose checks the Client's configuration step by step, stopping at
+// the first step that fails: whether Keycloak is reachable at the base
+// URL, whether the realm exists there, and whether the configured
+// credentials are granted a token. ctx should carry a deadline so an
+// unreachable host fails promptly.
+func (c *Client) Diagnose(ctx context.Context) *Diagnosis {
+	path, err := c.realmPath(".well-known", "openid-configuration")
+	if err != nil {
+		return &Diagnosis{FailedStep: DiagnoseRealm, Err: err}
+	}
+
+	req, err := c.newRequest(ctx, "GET", path, nil, headers{}, false)
+	if err != nil {
+		return &Diagnosis{FailedStep: DiagnoseReachability, Err: err}
+	}
+
+	if _, err := c.do(ctx, req, nil); err != nil {
+		var errResp *ErrorResponse
+		if !errors.As(err, &errResp) {
+			return &Diagnosis{FailedStep: DiagnoseReachability, Err: err}
+		}
+		if errResp.Response.StatusCode == http.StatusNotFound {
+			err = fmt.Errorf("keycloak: realm %q not found, check the realm name and that the base URL includes any path prefix such as /auth: %w", c.realm, err)
+		}
+		return &Diagnosis{FailedStep: DiagnoseRealm, Err: err}
+	}
+
+	if _, _, err := c.Authentication.GetOIDCToken(ctx, c.adminGrant()); err != nil {
+		return &Diagnosis{FailedStep: DiagnoseCredentials, Err: err}
+	}
+
+	return &Diagnosis{}
+}
@@ -0,0 +1,80 @@
+package keycloak
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Environment variables read by NewFromEnv
+const (
+	EnvBaseURL       = "KEYCLOAK_BASE_URL"
+	EnvRealm         = "KEYCLOAK_REALM"
+	EnvClientID      = "KEYCLOAK_CLIENT_ID"
+	EnvClientSecret  = "KEYCLOAK_CLIENT_SECRET"
+	EnvAdminUser     = "KEYCLOAK_ADMIN_USER"
+	EnvAdminPass     = "KEYCLOAK_ADMIN_PASS"
+	EnvOfflineAccess = "KEYCLOAK_OFFLINE_ACCESS"
+)
+
+// NewFromEnv builds a Client from environment variables using the default
+// HTTP client. KEYCLOAK_BASE_URL, KEYCLOAK_REALM, and KEYCLOAK_CLIENT_ID
+// are always required. The remaining variables select the constructor:
+//
+//   - KEYCLOAK_CLIENT_SECRET only: NewServiceAccount
+//   - KEYCLOAK_CLIENT_SECRET, KEYCLOAK_ADMIN_USER, and KEYCLOAK_ADMIN_PASS:
+//     NewConfidentialAdmin
+//   - KEYCLOAK_ADMIN_USER and KEYCLOAK_ADMIN_PASS only: NewPublicAdmin
+//
+// KEYCLOAK_OFFLINE_ACCESS, a boolean such as true or 1, optionally sets
+// hasOfflineAccess.
+func NewFromEnv(opts ...Option) (*Client, error) {
+	baseURL, err := requireEnv(EnvBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	realm, err := requireEnv(EnvRealm)
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := requireEnv(EnvClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasOfflineAccess := false
+	if v := os.Getenv(EnvOfflineAccess); v != "" {
+		hasOfflineAccess, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("keycloak: invalid %s %q: %v", EnvOfflineAccess, v, err)
+		}
+	}
+
+	clientSecret := os.Getenv(EnvClientSecret)
+	adminUser := os.Getenv(EnvAdminUser)
+	if adminUser == "" {
+		if clientSecret == "" {
+			return nil, fmt.Errorf("keycloak: %s or %s must be set", EnvClientSecret, EnvAdminUser)
+		}
+		return NewServiceAccount(nil, baseURL, realm, hasOfflineAccess, clientID, clientSecret, opts...)
+	}
+
+	adminPass, err := requireEnv(EnvAdminPass)
+	if err != nil {
+		return nil, err
+	}
+	if clientSecret == "" {
+		return NewPublicAdmin(nil, baseURL, realm, hasOfflineAccess, clientID, adminUser, adminPass, opts...)
+	}
+	return NewConfidentialAdmin(nil, baseURL, realm, hasOfflineAccess, clientID, clientSecret, adminUser, adminPass, opts...)
+}
+
+// requireEnv returns the value of the environment variable name or an
+// error if it is not set
+func requireEnv(name string) (string, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return "", fmt.Errorf("keycloak: %s must be set", name)
+	}
+	return v, nil
+}
@@ -32,7 +32,7 @@ func init() {
 func main() {
 	httpClient := &http.Client{}
 
-	serviceAccount := keycloak.NewServiceAccount(
+	serviceAccount, err := keycloak.NewServiceAccount(
 		httpClient,
 		os.Getenv("BASE_URL"),
 		os.Getenv("REALM"),
@@ -40,8 +40,11 @@ func main() {
 		os.Getenv("CLIENT_ID"),
 		os.Getenv("CLIENT_SECRET"),
 	)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	confidentialAdmin := keycloak.NewConfidentialAdmin(
+	confidentialAdmin, err := keycloak.NewConfidentialAdmin(
 		httpClient,
 		os.Getenv("BASE_URL"),
 		os.Getenv("REALM"),
@@ -51,8 +54,11 @@ func main() {
 		os.Getenv("ADMIN_USER"),
 		os.Getenv("ADMIN_PASS"),
 	)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	publicAdmin := keycloak.NewPublicAdmin(
+	publicAdmin, err := keycloak.NewPublicAdmin(
 		httpClient,
 		os.Getenv("BASE_URL"),
 		os.Getenv("REALM"),
@@ -61,6 +67,9 @@ func main() {
 		os.Getenv("ADMIN_USER"),
 		os.Getenv("ADMIN_PASS"),
 	)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	fmt.Println("Validating service acount:")
 	validate(serviceAccount)
@@ -74,7 +83,7 @@ func validate(kc *keycloak.Client) {
 	token, resp, err := kc.Authentication.GetOIDCToken(
 		context.Background(),
 		&keycloak.AccessGrantRequest{
-			GrantType: "password",
+			GrantType: keycloak.GrantPassword,
 			Username:  os.Getenv("EXAMPLE_USERNAME"),
 			Password:  os.Getenv("EXAMPLE_PASSWORD"),
 		},
@@ -0,0 +1,235 @@
+package keycloak
+
+import (
+	"context"
+	"strings"
+)
+
+// GroupService handles communication with keycloak group management
+type GroupService service
+
+// Group represents a Keycloak group
+type Group struct {
+	Access      *map[string]bool     `json:"access,omitempty"`
+	Attributes  *map[string][]string `json:"attributes,omitempty"`
+	ClientRoles *map[string][]string `json:"clientRoles,omitempty"`
+	ID          *string              `json:"id,omitempty"`
+	Name        *string              `json:"name,omitempty"`
+	Path        *string              `json:"path,omitempty"`
+	RealmRoles  *[]string            `json:"realmRoles,omitempty"`
+	SubGroups   *[]Group             `json:"subGroups,omitempty"`
+}
+
+// GetGroupsParams represents the optional search parameters of GetGroups
+type GetGroupsParams struct {
+	// BriefRepresentation set to false includes group attributes, which
+	// some Keycloak versions omit by default
+	BriefRepresentation *bool  `url:"briefRepresentation,omitempty"`
+	Exact               *bool  `url:"exact,omitempty"`
+	First               int    `url:"first,omitempty"`
+	Max                 int    `url:"max,omitempty"`
+	Search              string `url:"search,omitempty"`
+}
+
+// GetGroupMembersParams represents the optional paging parameters of
+// GetMembers
+type GetGroupMembersParams struct {
+	BriefRepresentation *bool `url:"briefRepresentation,omitempty"`
+	First               int   `url:"first,omitempty"`
+	Max                 int   `url:"max,omitempty"`
+}
+
+// membersCountPageSize is the number of members listed per request while
+// counting a group's members
+const membersCountPageSize = 1000
+
+// GetGroups retrieves the top level groups matching params
+func (c *GroupService) GetGroups(
+	ctx context.Context,
+	params *GetGroupsParams,
+) ([]*Group, *Response, error) {
+	path, err := c.client.adminPath(ctx, "groups")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var groups []*Group
+	resp, err := c.client.do(ctx, req, &groups)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return groups, resp, nil
+}
+
+// GetGroupByPath retrieves the group at a slash delimited path such as
+// /parent/child. Each group name in the path is escaped, so names may
+// contain spaces and other special characters. A group whose name
+// contains a slash cannot be looked up, and an empty, . or .. name is
+// rejected with an error rather than walking up the path.
+func (c *GroupService) GetGroupByPath(
+	ctx context.Context,
+	groupPath string,
+) (*Group, *Response, error) {
+	segments := append([]string{"group-by-path"}, strings.Split(strings.Trim(groupPath, "/"), "/")...)
+	path, err := c.client.adminPath(ctx, segments...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group := new(Group)
+	resp, err := c.client.do(ctx, req, group)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return group, resp, nil
+}
+
+// CreateChild creates child as a subgroup of the group with the given ID
+func (c *GroupService) CreateChild(
+	ctx context.Context,
+	parentID string,
+	child *Group,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "groups", parentID, "children")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, child, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// GetMembers retrieves the members of a group, paged by params. Keycloak
+// returns at most 100 members when params sets no Max.
+func (c *GroupService) GetMembers(
+	ctx context.Context,
+	groupID string,
+	params *GetGroupMembersParams,
+) ([]*User, *Response, error) {
+	path, err := c.client.adminPath(ctx, "groups", groupID, "members")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var members []*User
+	resp, err := c.client.do(ctx, req, &members)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return members, resp, nil
+}
+
+// GetMembersCount returns the number of members of a group. Keycloak has
+// no count endpoint for group members, so the members are listed page by
+// page and only their IDs are decoded.
+func (c *GroupService) GetMembersCount(
+	ctx context.Context,
+	groupID string,
+) (int, *Response, error) {
+	brief := true
+	params := &GetGroupMembersParams{BriefRepresentation: &brief, Max: membersCountPageSize}
+
+	count := 0
+	for {
+		path, err := c.client.adminPath(ctx, "groups", groupID, "members")
+		if err != nil {
+			return 0, nil, err
+		}
+		path, err = addParams(path, params)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		var ids []struct {
+			ID string `json:"id"`
+		}
+		resp, err := c.client.do(ctx, req, &ids)
+		if err != nil {
+			return 0, resp, err
+		}
+
+		count += len(ids)
+		if len(ids) < membersCountPageSize {
+			return count, resp, nil
+		}
+		params.First += membersCountPageSize
+	}
+}
+
+// MoveGroup moves a group, with its subgroups, under a new parent group,
+// or to the top level when newParentID is empty. Keycloak moves a group
+// when an existing group is created at its new place, so the group is
+// read first to send it with its current name.
+func (c *GroupService) MoveGroup(
+	ctx context.Context,
+	groupID string,
+	newParentID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "groups", groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	group := new(Group)
+	resp, err := c.client.do(ctx, req, group)
+	if err != nil {
+		return resp, err
+	}
+
+	moved := &Group{ID: group.ID, Name: group.Name}
+	if newParentID != "" {
+		return c.CreateChild(ctx, newParentID, moved)
+	}
+
+	path, err = c.client.adminPath(ctx, "groups")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err = c.client.newRequest(ctx, "POST", path, moved, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
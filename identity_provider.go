@@ -0,0 +1,128 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+)
+
+// IdentityProviderService handles communication with keycloak identity
+// provider management
+type IdentityProviderService service
+
+// IdentityProviderMapper represents a mapper that imports claims or
+// roles from an identity provider into Keycloak during brokering
+type IdentityProviderMapper struct {
+	Config                 *map[string]string `json:"config,omitempty"`
+	ID                     *string            `json:"id,omitempty"`
+	IdentityProviderAlias  *string            `json:"identityProviderAlias,omitempty"`
+	IdentityProviderMapper *string            `json:"identityProviderMapper,omitempty"`
+	Name                   *string            `json:"name,omitempty"`
+}
+
+// GetMappers retrieves the mappers of the identity provider with the
+// given alias
+func (c *IdentityProviderService) GetMappers(
+	ctx context.Context,
+	alias string,
+) ([]*IdentityProviderMapper, *Response, error) {
+	path, err := c.client.adminPath(ctx, "identity-provider", "instances", alias, "mappers")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mappers []*IdentityProviderMapper
+	resp, err := c.client.do(ctx, req, &mappers)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return mappers, resp, nil
+}
+
+// CreateMapper adds a mapper to the identity provider with the given
+// alias, e.g. a hardcoded-role-idp-mapper or oidc-role-idp-mapper that
+// grants a Keycloak role based on an external claim
+func (c *IdentityProviderService) CreateMapper(
+	ctx context.Context,
+	alias string,
+	mapper *IdentityProviderMapper,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "identity-provider", "instances", alias, "mappers")
+	if err != nil {
+		return nil, err
+	}
+
+	if mapper == nil {
+		return nil, errors.New("keycloak: identity provider mapper is required")
+	}
+
+	body := *mapper
+	if body.IdentityProviderAlias == nil {
+		body.IdentityProviderAlias = &alias
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, &body, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// DeleteMapper deletes a mapper from the identity provider with the given
+// alias
+func (c *IdentityProviderService) DeleteMapper(
+	ctx context.Context,
+	alias string,
+	mapperID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "identity-provider", "instances", alias, "mappers", mapperID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// ImportFromURL fetches the discovery document or metadata at fromURL and
+// returns it parsed into an identity provider config for providerID,
+// e.g. oidc or saml. Keycloak does not create a provider from it.
+func (c *IdentityProviderService) ImportFromURL(
+	ctx context.Context,
+	providerID string,
+	fromURL string,
+) (map[string]string, *Response, error) {
+	path, err := c.client.adminPath(ctx, "identity-provider", "import-config")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := map[string]string{
+		"fromUrl":    fromURL,
+		"providerId": providerID,
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, body, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Importing only reads the remote document so it is safe to repeat
+	config := make(map[string]string)
+	resp, err := c.client.do(WithRetryablePOST(ctx), req, &config)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return config, resp, nil
+}
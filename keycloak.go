@@ -3,13 +3,19 @@ package keycloak
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -18,10 +24,17 @@ const (
 	defaultAdminBase = "admin/realms"
 	defaultBase      = "realms"
 
-	formEncoded   = "application/x-www-form-urlencoded"
-	passwordGrant = "password"
-	clientGrant   = "client_credentials"
-	offlineScope  = "offline_access"
+	formEncoded  = "application/x-www-form-urlencoded"
+	jsonContent  = "application/json"
+	offlineScope = "offline_access"
+	openIDScope  = "openid"
+
+	// Cached tokens are renewed this long before they expire
+	tokenExpiryDelta = 10 * time.Second
+
+	// Default HTTP client settings
+	defaultTimeout             = 30 * time.Second
+	defaultMaxIdleConnsPerHost = 10
 )
 
 // Response is the Keycloak response.
@@ -29,16 +42,129 @@ type Response struct {
 	Response *http.Response
 }
 
+// ErrForbidden matches, with errors.Is, an ErrorResponse for a request
+// Keycloak rejected with 403 Forbidden. For admin requests this usually
+// means the admin account or service account lacks a realm-management
+// role required by the operation.
+var ErrForbidden = errors.New("keycloak: forbidden")
+
 // ErrorResponse returns the error response from Keycloak
 type ErrorResponse struct {
-	Response *http.Response
-	Message  string `json:"error_description"`
+	Response         *http.Response
+	ErrorCode        string       `json:"error"`
+	Message          string       `json:"error_description"`
+	ValidationErrors []FieldError `json:"errors,omitempty"`
+
+	// RequestID is the request or trace ID set by Keycloak or a proxy in
+	// front of it, if any, to correlate the error with server side logs
+	RequestID string `json:"-"`
+
+	// adminRequest reports whether the failed request targeted the admin API
+	adminRequest bool
+}
+
+// requestIDHeaders are the response headers checked, in order, for a
+// request ID to report in ErrorResponse
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Trace-Id", "Traceparent"}
+
+// responseRequestID returns the first request ID header set on resp
+func responseRequestID(resp *http.Response) string {
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// FieldError describes a single field that failed Keycloak validation
+type FieldError struct {
+	Field        string        `json:"field"`
+	ErrorMessage string        `json:"errorMessage"`
+	Params       []interface{} `json:"params,omitempty"` // strings and numbers, e.g. length bounds
 }
 
 func (r *ErrorResponse) Error() string {
+	message := r.Message
+	if message == "" {
+		message = r.ErrorCode
+	}
+	if len(r.ValidationErrors) > 0 {
+		fields := make([]string, len(r.ValidationErrors))
+		for i, fieldErr := range r.ValidationErrors {
+			fields[i] = fieldErr.Field + ": " + fieldErr.ErrorMessage
+		}
+		message += " (" + strings.Join(fields, ", ") + ")"
+	}
+	if r.adminRequest && r.Response.StatusCode == http.StatusForbidden {
+		message += " (check the account has the required realm-management roles)"
+	}
+	if r.RequestID != "" {
+		message += " [request ID " + r.RequestID + "]"
+	}
 	return fmt.Sprintf("%v %v: %d %v",
 		r.Response.Request.Method, r.Response.Request.URL,
-		r.Response.StatusCode, r.Message)
+		r.Response.StatusCode, strings.TrimSpace(message))
+}
+
+// Is reports whether target is ErrForbidden and Keycloak responded with
+// 403 Forbidden
+func (r *ErrorResponse) Is(target error) bool {
+	return target == ErrForbidden && r.Response.StatusCode == http.StatusForbidden
+}
+
+// parseErrorBody populates r from a Keycloak error body. Admin endpoints
+// report a single validation failure as a top level field error and
+// several as an errors array.
+func parseErrorBody(data []byte, r *ErrorResponse) {
+	if !decodeErrorBody(data, r) {
+		return
+	}
+
+	var fieldErr FieldError
+	if !decodeErrorBody(data, &fieldErr) {
+		return
+	}
+	if r.Message == "" {
+		r.Message = fieldErr.ErrorMessage
+	}
+	if fieldErr.Field != "" && len(r.ValidationErrors) == 0 {
+		r.ValidationErrors = []FieldError{fieldErr}
+	}
+}
+
+// decodeErrorBody unmarshals data into v and reports whether it is usable.
+// A field of an unexpected type only leaves that field unset, so the rest
+// of the body is still used.
+func decodeErrorBody(data []byte, v interface{}) bool {
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		return true
+	}
+	_, ok := err.(*json.UnmarshalTypeError)
+	return ok
+}
+
+// IsRetryable reports whether err is a transient transport failure that
+// may succeed if the request is sent again. Errors returned by Keycloak
+// are never retryable: repeating an invalid_grant or invalid_client token
+// request with the same credentials only counts towards Keycloak's brute
+// force detection and can lock the account out.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return false
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
 }
 
 // Client manages communication to Keycloak
@@ -61,18 +187,154 @@ type Client struct {
 	adminPass    string
 
 	// Services
-	Authentication *AuthenticationService
-	AdminUser      *AdminUserService
-	UMA            *UMAService
+	Account             *AccountService
+	Authentication      *AuthenticationService
+	AuthenticationFlows *AuthenticationFlowService
+	AdminUser           *AdminUserService
+	Clients             *ClientService
+	Groups              *GroupService
+	IdentityProviders   *IdentityProviderService
+	Organizations       *OrganizationService
+	Realms              *RealmService
+	Roles               *RoleService
+	UMA                 *UMAService
+
+	adminMu     sync.Mutex // Guards adminOIDC and adminExpiry
+	adminOIDC   *OIDCToken
+	adminExpiry time.Time
+
+	patMu     sync.Mutex // Guards pat and patExpiry
+	pat       *OIDCToken
+	patExpiry time.Time
+
+	userAgent string
+
+	retryMax          int
+	retryBackoff      time.Duration
+	retryServerErrors bool
+
+	minTLSVersion uint16 // only applied to the default HTTP client
+
+	tokenEndpoint string
+}
 
-	adminOIDC *OIDCToken
+// Option configures optional Client settings
+type Option func(*Client)
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) { c.userAgent = userAgent }
+}
+
+// WithTokenEndpoint overrides the path of the token endpoint used for
+// grants, realms/{realm}/protocol/openid-connect/token by default, for
+// deployments that expose it elsewhere through a gateway. path is
+// resolved against the base URL, so a path starting with a slash is
+// taken from the host root.
+func WithTokenEndpoint(path string) Option {
+	return func(c *Client) { c.tokenEndpoint = path }
+}
+
+// WithMinTLSVersion sets the minimum TLS version, e.g. tls.VersionTLS13,
+// of the default HTTP client used when no httpClient is given. It
+// defaults to TLS 1.2. A provided httpClient is used as is.
+func WithMinTLSVersion(version uint16) Option {
+	return func(c *Client) { c.minTLSVersion = version }
 }
 
 type service struct {
 	client *Client
 }
 
+type contextKey int
+
+const (
+	realmContextKey contextKey = iota
+	retryPOSTContextKey
+	queryParamsContextKey
+	noRetryContextKey
+)
+
+// WithRealm returns a context that directs admin requests made with it to
+// realm instead of the configured realm. The admin token is still issued
+// by the configured realm, so a master realm admin can manage other realms
+// with a single Client.
+func WithRealm(ctx context.Context, realm string) context.Context {
+	return context.WithValue(ctx, realmContextKey, realm)
+}
+
+// WithQueryParams returns a context that adds params to the query string
+// of requests made with it, alongside the parameters set by the method.
+// It allows using query filters added by newer Keycloak versions before
+// the method's params support them.
+func WithQueryParams(ctx context.Context, params url.Values) context.Context {
+	return context.WithValue(ctx, queryParamsContextKey, params)
+}
+
+// addContextParams adds the query params of ctx, if any, to req
+func addContextParams(ctx context.Context, req *http.Request) {
+	params, ok := ctx.Value(queryParamsContextKey).(url.Values)
+	if !ok || len(params) == 0 {
+		return
+	}
+
+	query := req.URL.Query()
+	for name, values := range params {
+		for _, value := range values {
+			query.Add(name, value)
+		}
+	}
+	req.URL.RawQuery = query.Encode()
+}
+
+// adminRealm returns the realm targeted by admin requests made with ctx
+func (c *Client) adminRealm(ctx context.Context) string {
+	if realm, ok := ctx.Value(realmContextKey).(string); ok && realm != "" {
+		return realm
+	}
+	return c.realm
+}
+
+// adminPath returns the path of an admin endpoint of the realm targeted by
+// ctx. Each segment is escaped and must be a single non-empty segment
+// other than . or .., which would otherwise be resolved away and send the
+// request to a parent endpoint.
+func (c *Client) adminPath(ctx context.Context, segments ...string) (string, error) {
+	return joinPath(defaultAdminBase, c.adminRealm(ctx), segments)
+}
+
+// realmPath returns the path of an endpoint of the configured realm. Its
+// segments are validated and escaped as by adminPath.
+func (c *Client) realmPath(segments ...string) (string, error) {
+	return joinPath(defaultBase, c.realm, segments)
+}
+
+// tokenPath returns the path of the token endpoint
+func (c *Client) tokenPath() (string, error) {
+	if c.tokenEndpoint != "" {
+		return c.tokenEndpoint, nil
+	}
+	return c.realmPath("protocol", "openid-connect", "token")
+}
+
+// joinPath joins base, realm, and segments into a path, escaping realm
+// and each segment. Empty, . and .. segments are rejected.
+func joinPath(base string, realm string, segments []string) (string, error) {
+	var b strings.Builder
+	b.WriteString(base)
+	for _, segment := range append([]string{realm}, segments...) {
+		switch segment {
+		case "", ".", "..":
+			return "", fmt.Errorf("keycloak: invalid path segment %q", segment)
+		}
+		b.WriteString("/")
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String(), nil
+}
+
 type headers struct {
+	accept        string
 	authorization string
 	contentType   string
 }
@@ -87,8 +349,10 @@ func NewServiceAccount(
 
 	clientID string,
 	clientSecret string,
-) *Client {
-	return newClient(httpClient, baseURL, realm, hasOfflineAccess, true, true, clientID, clientSecret, "", "")
+
+	opts ...Option,
+) (*Client, error) {
+	return newClient(httpClient, baseURL, realm, hasOfflineAccess, true, true, clientID, clientSecret, "", "", opts)
 }
 
 // NewConfidentialAdmin is targeted at users with elevated privileges
@@ -105,8 +369,10 @@ func NewConfidentialAdmin(
 
 	adminAccount string,
 	adminPass string,
-) *Client {
-	return newClient(httpClient, baseURL, realm, hasOfflineAccess, false, true, clientID, clientSecret, adminAccount, adminPass)
+
+	opts ...Option,
+) (*Client, error) {
+	return newClient(httpClient, baseURL, realm, hasOfflineAccess, false, true, clientID, clientSecret, adminAccount, adminPass, opts)
 }
 
 // NewPublicAdmin is targeted at users with elevated privileges who will
@@ -122,12 +388,15 @@ func NewPublicAdmin(
 
 	adminAccount string,
 	adminPass string,
-) *Client {
-	return newClient(httpClient, baseURL, realm, hasOfflineAccess, false, false, clientID, "", adminAccount, adminPass)
+
+	opts ...Option,
+) (*Client, error) {
+	return newClient(httpClient, baseURL, realm, hasOfflineAccess, false, false, clientID, "", adminAccount, adminPass, opts)
 }
 
 // newClient returns a new Keycloak consumer. If no httpClient is provided
-// the default httpClient will be used.
+// a dedicated default httpClient will be used. An error is returned when
+// baseURL is not an absolute URL.
 func newClient(
 	httpClient *http.Client,
 
@@ -148,13 +417,17 @@ func newClient(
 	// If using an admin account
 	adminAccount string,
 	adminPass string,
-) *Client {
 
-	if httpClient == nil {
-		httpClient = http.DefaultClient
-	}
+	opts []Option,
+) (*Client, error) {
 
-	base, _ := url.Parse(baseURL)
+	base, err := url.Parse(normalizeBaseURL(baseURL))
+	if err != nil {
+		return nil, fmt.Errorf("keycloak: invalid base URL %q: %v", baseURL, err)
+	}
+	if base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("keycloak: invalid base URL %q: must be absolute", baseURL)
+	}
 
 	c := &Client{
 		httpClient: httpClient,
@@ -171,39 +444,111 @@ func newClient(
 		adminAccount: adminAccount,
 		adminPass:    adminPass,
 		adminOIDC:    &OIDCToken{},
+
+		minTLSVersion: tls.VersionTLS12,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = newDefaultHTTPClient(c.minTLSVersion)
 	}
 
 	c.common.client = c
+	c.Account = (*AccountService)(&c.common)
 	c.Authentication = (*AuthenticationService)(&c.common)
+	c.AuthenticationFlows = (*AuthenticationFlowService)(&c.common)
 	c.AdminUser = (*AdminUserService)(&c.common)
+	c.Clients = (*ClientService)(&c.common)
+	c.Groups = (*GroupService)(&c.common)
+	c.IdentityProviders = (*IdentityProviderService)(&c.common)
+	c.Organizations = (*OrganizationService)(&c.common)
+	c.Realms = (*RealmService)(&c.common)
+	c.Roles = (*RoleService)(&c.common)
 	c.UMA = (*UMAService)(&c.common)
 
-	return c
+	return c, nil
+}
+
+// normalizeBaseURL ensures baseURL ends with a slash. Request paths are
+// resolved relative to the base URL, which would otherwise drop its last
+// path segment, e.g. the /auth of https://kc.example.com/auth.
+func normalizeBaseURL(baseURL string) string {
+	if strings.HasSuffix(baseURL, "/") {
+		return baseURL
+	}
+	return baseURL + "/"
+}
+
+// newDefaultHTTPClient returns an http.Client with its own transport so
+// connection pooling and timeouts are not shared with http.DefaultClient
+func newDefaultHTTPClient(minTLSVersion uint16) *http.Client {
+	var transport *http.Transport
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = t.Clone()
+	} else {
+		// http.DefaultTransport was replaced, e.g. by a mock or an
+		// instrumenting wrapper, so start from its standard settings
+		transport = &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		}
+	}
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.MinVersion = minTLSVersion
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   defaultTimeout,
+	}
 }
 
 // BaseURL returns the baseURL value
-func (c Client) BaseURL() string { return c.baseURL.String() }
+func (c *Client) BaseURL() string { return c.baseURL.String() }
 
 // Realm returns the realm value
-func (c Client) Realm() string { return c.realm }
+func (c *Client) Realm() string { return c.realm }
 
 // ClientID returns the clientID value
-func (c Client) ClientID() string { return c.clientID }
+func (c *Client) ClientID() string { return c.clientID }
 
 // ClientSecret returns the clientSecret value
-func (c Client) ClientSecret() string { return c.clientSecret }
+func (c *Client) ClientSecret() string { return c.clientSecret }
 
 // AdminAccount returns the adminAccount value
-func (c Client) AdminAccount() string { return c.adminAccount }
+func (c *Client) AdminAccount() string { return c.adminAccount }
 
 // AdminPass returns the adminPass value
-func (c Client) AdminPass() string { return c.adminPass }
+func (c *Client) AdminPass() string { return c.adminPass }
 
 // AdminOIDC returns the admin access token
-func (c Client) AdminOIDC() *OIDCToken { return c.adminOIDC }
+func (c *Client) AdminOIDC() *OIDCToken {
+	c.adminMu.Lock()
+	defer c.adminMu.Unlock()
+	return c.adminOIDC
+}
+
+// UserAgent returns the userAgent value
+func (c *Client) UserAgent() string { return c.userAgent }
 
 // newRequest creates the keycloak request with a relative URL provided.
+// Admin requests fetch the admin token with ctx, so the caller's deadline
+// bounds a token grant.
 func (c *Client) newRequest(
+	ctx context.Context,
 	method,
 	path string,
 	body interface{},
@@ -217,9 +562,12 @@ func (c *Client) newRequest(
 
 	var req *http.Request
 	if h.contentType == formEncoded && body != nil {
-		formEnc, err := query.Values(body)
-		if err != nil {
-			return nil, err
+		formEnc, ok := body.(url.Values)
+		if !ok {
+			formEnc, err = query.Values(body)
+			if err != nil {
+				return nil, err
+			}
 		}
 		form := strings.NewReader(formEnc.Encode())
 		req, err = http.NewRequest(method, u.String(), form)
@@ -244,48 +592,158 @@ func (c *Client) newRequest(
 		req.Header.Set("Content-Type", h.contentType)
 	}
 	if body != nil && h.contentType == "" {
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", jsonContent)
+	}
+	if h.accept != "" {
+		req.Header.Set("Accept", h.accept)
 	}
 	if h.authorization != "" {
 		req.Header.Set("Authorization", h.authorization)
 	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 	if isAdminRequest {
-		var token *OIDCToken
-		var err error
+		token, err := c.adminToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
-		adminGrant := &AccessGrantRequest{}
+	return req, nil
+}
 
-		if c.hasOfflineAccess {
-			adminGrant.Scope = offlineScope
-		}
+// adminToken returns the cached admin access token, granting a new one
+// when none is cached or the cached one is about to expire. The lock is
+// held for the grant so concurrent callers wait for a single token fetch.
+func (c *Client) adminToken(ctx context.Context) (string, error) {
+	c.adminMu.Lock()
+	defer c.adminMu.Unlock()
 
-		if c.isConfidential && c.isServiceAccount {
-			adminGrant.GrantType = clientGrant
+	if c.adminOIDC.AccessToken != "" && time.Now().Before(c.adminExpiry) {
+		return c.adminOIDC.AccessToken, nil
+	}
 
-			token, _, err = c.Authentication.GetOIDCToken(
-				context.Background(),
-				adminGrant,
-			)
-		} else {
-			adminGrant.GrantType = passwordGrant
-			adminGrant.Username = c.adminAccount
-			adminGrant.Password = c.adminPass
-
-			token, _, err = c.Authentication.GetOIDCToken(
-				context.Background(),
-				adminGrant,
-			)
+	// Repeating a grant has no side effects so it is always retryable. The
+	// caller's extra query params are meant for its own request only.
+	grantCtx := WithQueryParams(WithRetryablePOST(ctx), nil)
+	token, _, err := c.Authentication.GetOIDCToken(grantCtx, c.adminGrant())
+	if err != nil {
+		return "", err
+	}
+
+	c.adminOIDC = token
+	c.adminExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryDelta)
+
+	return token.AccessToken, nil
+}
+
+// dropAdminToken clears the cached admin token if it is the one sent in
+// authorization, so the next admin request grants a new token. Keycloak
+// rejects a token before it expires once it is revoked or the server
+// restarts with new keys.
+func (c *Client) dropAdminToken(authorization string) {
+	c.adminMu.Lock()
+	defer c.adminMu.Unlock()
+
+	if c.adminOIDC.AccessToken != "" && authorization == "Bearer "+c.adminOIDC.AccessToken {
+		c.adminOIDC = &OIDCToken{}
+		c.adminExpiry = time.Time{}
+	}
+}
+
+// adminGrant returns the grant request for the admin token
+func (c *Client) adminGrant() *AccessGrantRequest {
+	grant := &AccessGrantRequest{Scope: c.grantScope("")}
+
+	if c.isConfidential && c.isServiceAccount {
+		grant.GrantType = GrantClientCredentials
+	} else {
+		grant.GrantType = GrantPassword
+		grant.Username = c.adminAccount
+		grant.Password = c.adminPass
+	}
+
+	return grant
+}
+
+// grantScope returns scope for a grant made with the configured
+// credentials, adding offline_access when the account has offline access.
+// Every such grant goes through it so the scopes requested never differ.
+func (c *Client) grantScope(scope string) string {
+	if !c.hasOfflineAccess {
+		return scope
+	}
+	return addScope(scope, offlineScope)
+}
+
+// addScope returns the space separated scope with add appended, unless
+// it is already present
+func addScope(scope string, add string) string {
+	for _, s := range strings.Fields(scope) {
+		if s == add {
+			return scope
 		}
+	}
+	return strings.TrimSpace(scope + " " + add)
+}
+
+// addParams encodes the url tagged fields of params into the query
+// string of path. A nil params leaves path unchanged.
+func addParams(path string, params interface{}) (string, error) {
+	if v := reflect.ValueOf(params); v.Kind() == reflect.Ptr && v.IsNil() {
+		return path, nil
+	}
 
+	values, err := query.Values(params)
+	if err != nil {
+		return path, err
+	}
+	if len(values) == 0 {
+		return path, nil
+	}
+
+	return path + "?" + values.Encode(), nil
+}
+
+// BuildRequest returns the fully-formed request for a relative path
+// without sending it, which allows inspecting the URL, headers, and body.
+// Admin request paths, e.g. users/{id}, are relative to the admin
+// endpoint of the realm targeted by ctx, and an admin token is fetched
+// to resolve the Authorization header. Other paths are relative to the
+// base URL. Query parameters added with WithQueryParams are applied as
+// they would be when sending.
+func (c *Client) BuildRequest(
+	ctx context.Context,
+	method,
+	path string,
+	body interface{},
+	isAdminRequest bool,
+) (*http.Request, error) {
+	if isAdminRequest {
+		base, err := c.adminPath(ctx)
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		path = base + "/" + strings.TrimPrefix(path, "/")
 	}
 
+	req, err := c.newRequest(ctx, method, path, body, headers{}, isAdminRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+	addContextParams(ctx, req)
 	return req, nil
 }
 
+// isAdminRequest reports whether req targets the admin API
+func (c *Client) isAdminRequest(req *http.Request) bool {
+	return strings.HasPrefix(req.URL.Path, c.baseURL.Path+"admin/")
+}
+
 // do sends a keycloak request and returns the repsonse.
 func (c *Client) do(
 	ctx context.Context,
@@ -293,25 +751,36 @@ func (c *Client) do(
 	v interface{},
 ) (*Response, error) {
 	req = req.WithContext(ctx)
+	addContextParams(ctx, req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
 		}
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	response := &Response{Response: resp}
 
+	adminRequest := c.isAdminRequest(req)
+	if adminRequest && resp.StatusCode == http.StatusUnauthorized {
+		c.dropAdminToken(req.Header.Get("Authorization"))
+	}
+
 	if c := resp.StatusCode; c >= 300 {
-		errorResponse := &ErrorResponse{Response: resp}
+		errorResponse := &ErrorResponse{
+			Response:     resp,
+			RequestID:    responseRequestID(resp),
+			adminRequest: adminRequest,
+		}
 
 		data, err := ioutil.ReadAll(resp.Body)
 		if err == nil && data != nil {
-			json.Unmarshal(data, errorResponse)
+			parseErrorBody(data, errorResponse)
 		}
 
 		return nil, errorResponse
@@ -319,7 +788,7 @@ func (c *Client) do(
 
 	if v != nil {
 		if w, ok := v.(io.Writer); ok {
-			io.Copy(w, resp.Body)
+			_, err = io.Copy(w, resp.Body)
 		} else {
 			decErr := json.NewDecoder(resp.Body).Decode(v)
 			if decErr == io.EOF {
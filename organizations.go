@@ -0,0 +1,138 @@
+package keycloak
+
+import "context"
+
+// OrganizationService handles communication with keycloak organization
+// management, available from Keycloak 24 when organizations are enabled
+type OrganizationService service
+
+// Organization represents a Keycloak organization
+type Organization struct {
+	Alias       *string               `json:"alias,omitempty"`
+	Attributes  *map[string][]string  `json:"attributes,omitempty"`
+	Description *string               `json:"description,omitempty"`
+	Domains     *[]OrganizationDomain `json:"domains,omitempty"`
+	Enabled     *bool                 `json:"enabled,omitempty"`
+	ID          *string               `json:"id,omitempty"`
+	Name        *string               `json:"name,omitempty"`
+	RedirectURL *string               `json:"redirectUrl,omitempty"`
+}
+
+// OrganizationDomain represents an internet domain owned by an organization
+type OrganizationDomain struct {
+	Name     *string `json:"name,omitempty"`
+	Verified *bool   `json:"verified,omitempty"`
+}
+
+// GetOrganizationsParams represents the optional search parameters of
+// GetOrganizations
+type GetOrganizationsParams struct {
+	BriefRepresentation *bool  `url:"briefRepresentation,omitempty"`
+	Exact               *bool  `url:"exact,omitempty"`
+	First               int    `url:"first,omitempty"`
+	Max                 int    `url:"max,omitempty"`
+	Search              string `url:"search,omitempty"`
+}
+
+// GetOrganizationMembersParams represents the optional search parameters
+// of GetMembers
+type GetOrganizationMembersParams struct {
+	Exact  *bool  `url:"exact,omitempty"`
+	First  int    `url:"first,omitempty"`
+	Max    int    `url:"max,omitempty"`
+	Search string `url:"search,omitempty"`
+}
+
+// GetOrganizations retrieves the organizations matching params
+func (c *OrganizationService) GetOrganizations(
+	ctx context.Context,
+	params *GetOrganizationsParams,
+) ([]*Organization, *Response, error) {
+	path, err := c.client.adminPath(ctx, "organizations")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var orgs []*Organization
+	resp, err := c.client.do(ctx, req, &orgs)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return orgs, resp, nil
+}
+
+// CreateOrganization creates a new organization
+func (c *OrganizationService) CreateOrganization(
+	ctx context.Context,
+	org *Organization,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "organizations")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, org, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// AddMember adds an existing user to an organization
+func (c *OrganizationService) AddMember(
+	ctx context.Context,
+	orgID string,
+	userID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "organizations", orgID, "members")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, userID, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// GetMembers retrieves the members of an organization matching params
+func (c *OrganizationService) GetMembers(
+	ctx context.Context,
+	orgID string,
+	params *GetOrganizationMembersParams,
+) ([]*User, *Response, error) {
+	path, err := c.client.adminPath(ctx, "organizations", orgID, "members")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var members []*User
+	resp, err := c.client.do(ctx, req, &members)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return members, resp, nil
+}
@@ -0,0 +1,514 @@
+package keycloak
+
+import (
+	"context"
+	"io"
+)
+
+// RealmService handles communication with keycloak realm management
+type RealmService service
+
+// RequiredActionProvider represents a configured required action
+type RequiredActionProvider struct {
+	Alias         *string            `json:"alias,omitempty"`
+	Config        *map[string]string `json:"config,omitempty"`
+	DefaultAction *bool              `json:"defaultAction,omitempty"`
+	Enabled       *bool              `json:"enabled,omitempty"`
+	Name          *string            `json:"name,omitempty"`
+	Priority      *int32             `json:"priority,omitempty"`
+	ProviderID    *string            `json:"providerId,omitempty"`
+}
+
+// GetRequiredActions retrieves the realm's required action providers
+func (c *RealmService) GetRequiredActions(
+	ctx context.Context,
+) ([]*RequiredActionProvider, *Response, error) {
+	path, err := c.client.adminPath(ctx, "authentication", "required-actions")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var actions []*RequiredActionProvider
+	resp, err := c.client.do(ctx, req, &actions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return actions, resp, nil
+}
+
+// ClearAllBruteForce clears the login failures of every user in the
+// realm, unlocking all temporarily disabled users
+func (c *RealmService) ClearAllBruteForce(ctx context.Context) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "attack-detection", "brute-force", "users")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// GlobalRequestResult represents the outcome of a request Keycloak pushes
+// to the admin URLs of every client
+type GlobalRequestResult struct {
+	FailedRequests  *[]string `json:"failedRequests,omitempty"`
+	SuccessRequests *[]string `json:"successRequests,omitempty"`
+}
+
+// PushRevocation pushes the realm's not-before policy to every client
+// with an admin URL, revoking tokens issued before it
+func (c *RealmService) PushRevocation(ctx context.Context) (*GlobalRequestResult, *Response, error) {
+	path, err := c.client.adminPath(ctx, "push-revocation")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(GlobalRequestResult)
+	resp, err := c.client.do(ctx, req, result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+// LogoutAll removes every user session in the realm and notifies clients
+// with an admin URL
+func (c *RealmService) LogoutAll(ctx context.Context) (*GlobalRequestResult, *Response, error) {
+	path, err := c.client.adminPath(ctx, "logout-all")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(GlobalRequestResult)
+	resp, err := c.client.do(ctx, req, result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+// ExportRealmOptions represents the optional parts of a realm export
+type ExportRealmOptions struct {
+	ExportClients        bool `url:"exportClients,omitempty"`
+	ExportGroupsAndRoles bool `url:"exportGroupsAndRoles,omitempty"`
+}
+
+// ExportRealm writes the realm's configuration to w as JSON, including
+// the parts selected by opts. Users are never exported and secrets are
+// masked by Keycloak. As with StreamUsers, an error is returned if the
+// body is cut off, and the default HTTP client's timeout includes reading
+// the body.
+func (c *RealmService) ExportRealm(
+	ctx context.Context,
+	w io.Writer,
+	opts *ExportRealmOptions,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "partial-export")
+	if err != nil {
+		return nil, err
+	}
+	path, err = addParams(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Exporting only reads the realm so it is safe to repeat
+	return c.client.do(WithRetryablePOST(ctx), req, w)
+}
+
+// ClientScope represents a client scope of a realm
+type ClientScope struct {
+	Attributes  *map[string]string `json:"attributes,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	ID          *string            `json:"id,omitempty"`
+	Name        *string            `json:"name,omitempty"`
+	Protocol    *string            `json:"protocol,omitempty"`
+}
+
+// GetDefaultDefaultClientScopes retrieves the client scopes newly created
+// clients are assigned as default scopes
+func (c *RealmService) GetDefaultDefaultClientScopes(
+	ctx context.Context,
+) ([]*ClientScope, *Response, error) {
+	return c.getDefaultClientScopes(ctx, "default-default-client-scopes")
+}
+
+// GetDefaultOptionalClientScopes retrieves the client scopes newly
+// created clients are assigned as optional scopes
+func (c *RealmService) GetDefaultOptionalClientScopes(
+	ctx context.Context,
+) ([]*ClientScope, *Response, error) {
+	return c.getDefaultClientScopes(ctx, "default-optional-client-scopes")
+}
+
+// getDefaultClientScopes retrieves the client scopes listed by endpoint
+func (c *RealmService) getDefaultClientScopes(
+	ctx context.Context,
+	endpoint string,
+) ([]*ClientScope, *Response, error) {
+	path, err := c.client.adminPath(ctx, endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var scopes []*ClientScope
+	resp, err := c.client.do(ctx, req, &scopes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return scopes, resp, nil
+}
+
+// RealmKeys represents the keys of a realm
+type RealmKeys struct {
+	// Active maps each algorithm to the kid of the key currently used to
+	// sign with it
+	Active *map[string]string `json:"active,omitempty"`
+	Keys   *[]RealmKey        `json:"keys,omitempty"`
+}
+
+// RealmKey represents a key of a realm. Passive keys no longer sign new
+// tokens but still verify tokens signed before a rotation.
+type RealmKey struct {
+	Algorithm        *string    `json:"algorithm,omitempty"`
+	Certificate      *string    `json:"certificate,omitempty"`
+	Kid              *string    `json:"kid,omitempty"`
+	ProviderID       *string    `json:"providerId,omitempty"`
+	ProviderPriority *int64     `json:"providerPriority,omitempty"`
+	PublicKey        *string    `json:"publicKey,omitempty"`
+	Status           *string    `json:"status,omitempty"` // ACTIVE, PASSIVE, or DISABLED
+	Type             *string    `json:"type,omitempty"`
+	Use              *string    `json:"use,omitempty"`
+	ValidTo          *Timestamp `json:"validTo,omitempty"`
+}
+
+// GetKeys retrieves the metadata of the realm's active and passive keys
+func (c *RealmService) GetKeys(ctx context.Context) (*RealmKeys, *Response, error) {
+	path, err := c.client.adminPath(ctx, "keys")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := new(RealmKeys)
+	resp, err := c.client.do(ctx, req, keys)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return keys, resp, nil
+}
+
+// ClientPolicies represents the client policies of a realm
+type ClientPolicies struct {
+	GlobalPolicies *[]ClientPolicy `json:"globalPolicies,omitempty"`
+	Policies       *[]ClientPolicy `json:"policies,omitempty"`
+}
+
+// ClientPolicy represents a client policy, which applies its profiles to
+// clients matching all of its conditions
+type ClientPolicy struct {
+	Conditions  *[]ClientPolicyCondition `json:"conditions,omitempty"`
+	Description *string                  `json:"description,omitempty"`
+	Enabled     *bool                    `json:"enabled,omitempty"`
+	Name        *string                  `json:"name,omitempty"`
+	Profiles    *[]string                `json:"profiles,omitempty"`
+}
+
+// ClientPolicyCondition represents a condition of a client policy
+type ClientPolicyCondition struct {
+	Condition     *string                 `json:"condition,omitempty"`
+	Configuration *map[string]interface{} `json:"configuration,omitempty"`
+}
+
+// ClientProfiles represents the client profiles of a realm
+type ClientProfiles struct {
+	GlobalProfiles *[]ClientProfile `json:"globalProfiles,omitempty"`
+	Profiles       *[]ClientProfile `json:"profiles,omitempty"`
+}
+
+// ClientProfile represents a client profile, a set of executors enforced
+// on the clients of the policies using it, e.g. fapi-1-advanced
+type ClientProfile struct {
+	Description *string                  `json:"description,omitempty"`
+	Executors   *[]ClientProfileExecutor `json:"executors,omitempty"`
+	Name        *string                  `json:"name,omitempty"`
+}
+
+// ClientProfileExecutor represents an executor of a client profile
+type ClientProfileExecutor struct {
+	Configuration *map[string]interface{} `json:"configuration,omitempty"`
+	Executor      *string                 `json:"executor,omitempty"`
+}
+
+// clientPoliciesParams represents the query parameters of
+// GetClientPolicies
+type clientPoliciesParams struct {
+	IncludeGlobalPolicies bool `url:"include-global-policies"`
+}
+
+// GetClientPolicies retrieves the realm's client policies, including the
+// global policies built into Keycloak
+func (c *RealmService) GetClientPolicies(ctx context.Context) (*ClientPolicies, *Response, error) {
+	path, err := c.client.adminPath(ctx, "client-policies", "policies")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, &clientPoliciesParams{IncludeGlobalPolicies: true})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policies := new(ClientPolicies)
+	resp, err := c.client.do(ctx, req, policies)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return policies, resp, nil
+}
+
+// clientProfilesParams represents the query parameters of
+// GetClientProfiles
+type clientProfilesParams struct {
+	IncludeGlobalProfiles bool `url:"include-global-profiles"`
+}
+
+// GetClientProfiles retrieves the realm's client profiles, including the
+// global profiles built into Keycloak
+func (c *RealmService) GetClientProfiles(ctx context.Context) (*ClientProfiles, *Response, error) {
+	path, err := c.client.adminPath(ctx, "client-policies", "profiles")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, &clientProfilesParams{IncludeGlobalProfiles: true})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	profiles := new(ClientProfiles)
+	resp, err := c.client.do(ctx, req, profiles)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return profiles, resp, nil
+}
+
+// Event represents a user event recorded by the realm, e.g. LOGIN or
+// LOGIN_ERROR
+type Event struct {
+	ClientID  *string            `json:"clientId,omitempty"`
+	Details   *map[string]string `json:"details,omitempty"`
+	Error     *string            `json:"error,omitempty"`
+	IPAddress *string            `json:"ipAddress,omitempty"`
+	RealmID   *string            `json:"realmId,omitempty"`
+	SessionID *string            `json:"sessionId,omitempty"`
+	Time      *Timestamp         `json:"time,omitempty"`
+	Type      *string            `json:"type,omitempty"`
+	UserID    *string            `json:"userId,omitempty"`
+}
+
+// GetEventsParams represents the optional search parameters of GetEvents.
+// Set Max to bound the number of events returned; Keycloak returns at
+// most 100 when it is unset.
+type GetEventsParams struct {
+	Client    string   `url:"client,omitempty"`
+	DateFrom  string   `url:"dateFrom,omitempty"` // yyyy-MM-dd
+	DateTo    string   `url:"dateTo,omitempty"`   // yyyy-MM-dd
+	First     int      `url:"first,omitempty"`
+	IPAddress string   `url:"ipAddress,omitempty"`
+	Max       int      `url:"max,omitempty"`
+	Types     []string `url:"type,omitempty"` // sent as one type param per value
+	User      string   `url:"user,omitempty"`
+}
+
+// GetEvents retrieves the realm's user events matching params, most
+// recent first
+func (c *RealmService) GetEvents(
+	ctx context.Context,
+	params *GetEventsParams,
+) ([]*Event, *Response, error) {
+	path, err := c.client.adminPath(ctx, "events")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var events []*Event
+	resp, err := c.client.do(ctx, req, &events)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return events, resp, nil
+}
+
+// EventsConfig represents the event logging settings of a realm
+type EventsConfig struct {
+	AdminEventsDetailsEnabled *bool     `json:"adminEventsDetailsEnabled,omitempty"`
+	AdminEventsEnabled        *bool     `json:"adminEventsEnabled,omitempty"`
+	EnabledEventTypes         *[]string `json:"enabledEventTypes,omitempty"`
+	EventsEnabled             *bool     `json:"eventsEnabled,omitempty"`
+	EventsExpiration          *int64    `json:"eventsExpiration,omitempty"` // retention in seconds
+	EventsListeners           *[]string `json:"eventsListeners,omitempty"`
+}
+
+// GetEventsConfig retrieves the realm's event logging settings
+func (c *RealmService) GetEventsConfig(ctx context.Context) (*EventsConfig, *Response, error) {
+	path, err := c.client.adminPath(ctx, "events", "config")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := new(EventsConfig)
+	resp, err := c.client.do(ctx, req, config)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return config, resp, nil
+}
+
+// UpdateEventsConfig updates the realm's event logging settings, e.g. to
+// enable admin events. Keycloak replaces the whole configuration, so the
+// current settings are read first and the non-nil fields of config are
+// applied on top of them. Like UpdateUserFunc this is an unguarded
+// read-modify-write.
+func (c *RealmService) UpdateEventsConfig(
+	ctx context.Context,
+	config *EventsConfig,
+) (*Response, error) {
+	current, resp, err := c.GetEventsConfig(ctx)
+	if err != nil {
+		return resp, err
+	}
+	current.merge(config)
+
+	path, err := c.client.adminPath(ctx, "events", "config")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "PUT", path, current, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// merge sets the fields of e that are set in update
+func (e *EventsConfig) merge(update *EventsConfig) {
+	if update == nil {
+		return
+	}
+	if update.AdminEventsDetailsEnabled != nil {
+		e.AdminEventsDetailsEnabled = update.AdminEventsDetailsEnabled
+	}
+	if update.AdminEventsEnabled != nil {
+		e.AdminEventsEnabled = update.AdminEventsEnabled
+	}
+	if update.EnabledEventTypes != nil {
+		e.EnabledEventTypes = update.EnabledEventTypes
+	}
+	if update.EventsEnabled != nil {
+		e.EventsEnabled = update.EventsEnabled
+	}
+	if update.EventsExpiration != nil {
+		e.EventsExpiration = update.EventsExpiration
+	}
+	if update.EventsListeners != nil {
+		e.EventsListeners = update.EventsListeners
+	}
+}
+
+// ClearEvents deletes every user event recorded by the realm
+func (c *RealmService) ClearEvents(ctx context.Context) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "events")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// ClearAdminEvents deletes every admin event recorded by the realm
+func (c *RealmService) ClearAdminEvents(ctx context.Context) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "admin-events")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
@@ -0,0 +1,130 @@
+package keycloak
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// WithRetry retries requests that fail with a transient transport error up
+// to maxRetries times, waiting backoff before the first retry and doubling
+// the wait for each one after it.
+//
+// Only requests that are safe to repeat are retried: GET, HEAD, OPTIONS,
+// PUT, and DELETE, and the Client's own admin token grant. Other POST
+// requests, such as CreateUser, may have been processed by Keycloak
+// before the connection failed, so repeating them could create
+// duplicates. Use WithRetryablePOST to opt a POST into retries when
+// repeating it is safe. Requests with side effects beyond the admin API,
+// such as ExecuteActionsEmail and SendVerifyEmail, are never retried.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retryMax = maxRetries
+		c.retryBackoff = backoff
+	}
+}
+
+// WithRetryOnServerErrors also retries requests that Keycloak answers with
+// 500, 502, 503, or 504, which nodes return while draining during a
+// rolling restart. It only takes effect together with WithRetry and
+// follows the same rules for which requests are safe to repeat, so
+// non-idempotent operations, e.g. a POST or a PUT that sends an email,
+// are not retried on a 5xx, which may come after the server did the
+// work. Client errors (4xx) are never retried.
+func WithRetryOnServerErrors() Option {
+	return func(c *Client) { c.retryServerErrors = true }
+}
+
+// WithRetryablePOST returns a context that allows POST requests made with
+// it to be retried. Only use it for requests that are safe to repeat.
+func WithRetryablePOST(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryPOSTContextKey, true)
+}
+
+// withoutRetry returns a context whose requests are never retried, for
+// requests that are not idempotent whatever their method
+func withoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryContextKey, true)
+}
+
+// isRetryableStatus reports whether a response status is a transient
+// server failure
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// canRetry reports whether req may be sent again after a failure
+func canRetry(req *http.Request) bool {
+	if noRetry, _ := req.Context().Value(noRetryContextKey).(bool); noRetry {
+		return false
+	}
+
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	case "POST":
+		allowed, _ := req.Context().Value(retryPOSTContextKey).(bool)
+		return allowed
+	}
+	return false
+}
+
+// send performs req, retrying transient failures when retries are
+// configured and req is safe to repeat
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		if attempt >= c.retryMax || !canRetry(req) {
+			return resp, err
+		}
+		if err == nil {
+			if !c.retryServerErrors || !isRetryableStatus(resp.StatusCode) {
+				return resp, nil
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		} else if !IsRetryable(err) {
+			return resp, err
+		}
+
+		if err := sleep(req.Context(), c.retryBackoff<<uint(attempt)); err != nil {
+			return nil, err
+		}
+
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			retry.Body = body
+		}
+		req = retry
+	}
+}
+
+// sleep waits for d, returning early with the context's error if ctx is
+// done first. A context that is already done never waits, even for a zero
+// backoff whose timer would race it in the select.
+func sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
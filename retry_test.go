@@ -0,0 +1,37 @@
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSendVerifyEmailIsNotRetried(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/token") {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"token","expires_in":300}`))
+			return
+		}
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c, err := NewServiceAccount(srv.Client(), srv.URL, "test", false, "client", "secret",
+		WithRetry(2, 0), WithRetryOnServerErrors())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.AdminUser.SendVerifyEmail(context.Background(), "user", nil); err == nil {
+		t.Fatal("expected an error for 503 Service Unavailable")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+}
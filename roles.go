@@ -0,0 +1,102 @@
+package keycloak
+
+import "context"
+
+// RoleService handles communication with keycloak role management
+type RoleService service
+
+// Role represents a Keycloak realm or client role
+type Role struct {
+	Attributes  *map[string][]string `json:"attributes,omitempty"`
+	ClientRole  *bool                `json:"clientRole,omitempty"`
+	Composite   *bool                `json:"composite,omitempty"`
+	Composites  *RoleComposites      `json:"composites,omitempty"`
+	ContainerID *string              `json:"containerId,omitempty"`
+	Description *string              `json:"description,omitempty"`
+	ID          *string              `json:"id,omitempty"`
+	Name        *string              `json:"name,omitempty"`
+}
+
+// RoleComposites represents the roles that make up a composite role
+type RoleComposites struct {
+	Client *map[string][]string `json:"client,omitempty"`
+	Realm  *[]string            `json:"realm,omitempty"`
+}
+
+// GetClientRolesByClientID retrieves the roles of the client with the
+// given clientId, e.g. the manage-users and view-clients roles of
+// realm-management, resolving the client's internal UUID first
+func (c *RoleService) GetClientRolesByClientID(
+	ctx context.Context,
+	clientID string,
+) ([]*Role, *Response, error) {
+	clientUUID, resp, err := c.client.Clients.GetClientUUID(ctx, clientID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	path, err := c.client.adminPath(ctx, "clients", clientUUID, "roles")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var roles []*Role
+	resp, err = c.client.do(ctx, req, &roles)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return roles, resp, nil
+}
+
+// GetRealmRole retrieves the realm role with the given name
+func (c *RoleService) GetRealmRole(
+	ctx context.Context,
+	name string,
+) (*Role, *Response, error) {
+	path, err := c.client.adminPath(ctx, "roles", name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := new(Role)
+	resp, err := c.client.do(ctx, req, role)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return role, resp, nil
+}
+
+// ResolveRoles looks up the realm roles with the given names and returns
+// their full representations, as required by role mapping methods such
+// as AddRealmRoles. The first role that cannot be retrieved aborts the
+// lookup.
+func (c *RoleService) ResolveRoles(
+	ctx context.Context,
+	names []string,
+) ([]*Role, *Response, error) {
+	roles := make([]*Role, 0, len(names))
+
+	var resp *Response
+	for _, name := range names {
+		role, roleResp, err := c.GetRealmRole(ctx, name)
+		if err != nil {
+			return nil, roleResp, err
+		}
+		roles = append(roles, role)
+		resp = roleResp
+	}
+
+	return roles, resp, nil
+}
@@ -0,0 +1,158 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+const serverInfoPath = "admin/serverinfo"
+
+// ErrUnknownFeature is returned by IsFeatureEnabled when the server does
+// not know the requested feature
+var ErrUnknownFeature = errors.New("keycloak: unknown feature")
+
+// defaultFeatures lists the features enabled by default that servers
+// reporting only a ProfileInfo leave out of its feature lists
+var defaultFeatures = map[string]bool{
+	"ACCOUNT_API":            true,
+	"ACCOUNT2":               true,
+	"ADMIN_API":              true,
+	"ADMIN2":                 true,
+	"AUTHORIZATION":          true,
+	"CIBA":                   true,
+	"CLIENT_POLICIES":        true,
+	"DEVICE_FLOW":            true,
+	"IMPERSONATION":          true,
+	"JS_ADAPTER":             true,
+	"KERBEROS":               true,
+	"PAR":                    true,
+	"STEP_UP_AUTHENTICATION": true,
+	"WEB_AUTHN":              true,
+}
+
+// ServerInfo represents the Keycloak server information
+type ServerInfo struct {
+	Features    *[]Feature   `json:"features,omitempty"`
+	ProfileInfo *ProfileInfo `json:"profileInfo,omitempty"`
+	SystemInfo  *SystemInfo  `json:"systemInfo,omitempty"`
+}
+
+// Feature represents a server feature and whether it is enabled
+type Feature struct {
+	Enabled *bool   `json:"enabled,omitempty"`
+	Label   *string `json:"label,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	Type    *string `json:"type,omitempty"`
+}
+
+// ProfileInfo represents the features disabled or previewed by the
+// server profile
+type ProfileInfo struct {
+	DisabledFeatures     *[]string `json:"disabledFeatures,omitempty"`
+	ExperimentalFeatures *[]string `json:"experimentalFeatures,omitempty"`
+	Name                 *string   `json:"name,omitempty"`
+	PreviewFeatures      *[]string `json:"previewFeatures,omitempty"`
+}
+
+// SystemInfo represents the server version and runtime
+type SystemInfo struct {
+	ServerTime *string `json:"serverTime,omitempty"`
+	Uptime     *string `json:"uptime,omitempty"`
+	Version    *string `json:"version,omitempty"`
+}
+
+// GetServerInfo retrieves the server information
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, *Response, error) {
+	req, err := c.newRequest(ctx, "GET", serverInfoPath, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := new(ServerInfo)
+	resp, err := c.do(ctx, req, info)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return info, resp, nil
+}
+
+// IsFeatureEnabled reports whether the server has the given profile
+// feature enabled. The feature may be given either as its name, e.g.
+// TOKEN_EXCHANGE, or as its key, e.g. token-exchange. ErrUnknownFeature
+// is returned for a feature the server does not know.
+func (c *Client) IsFeatureEnabled(ctx context.Context, feature string) (bool, *Response, error) {
+	info, resp, err := c.GetServerInfo(ctx)
+	if err != nil {
+		return false, resp, err
+	}
+
+	enabled, err := info.featureEnabled(feature)
+	if err != nil {
+		return false, resp, err
+	}
+
+	return enabled, resp, nil
+}
+
+func (info *ServerInfo) featureEnabled(feature string) (bool, error) {
+	name := strings.ToUpper(strings.Replace(feature, "-", "_", -1))
+
+	// Newer servers list every feature with its state
+	if info.Features != nil {
+		for _, f := range *info.Features {
+			if f.Name != nil && *f.Name == name {
+				return f.Enabled != nil && *f.Enabled, nil
+			}
+		}
+		return false, ErrUnknownFeature
+	}
+
+	// Older servers only list the features they disable and the preview
+	// and experimental ones; the default features are implied
+	if info.ProfileInfo != nil {
+		if containsString(info.ProfileInfo.DisabledFeatures, name) {
+			return false, nil
+		}
+		if containsString(info.ProfileInfo.PreviewFeatures, name) ||
+			containsString(info.ProfileInfo.ExperimentalFeatures, name) {
+			return true, nil
+		}
+	}
+	if defaultFeatures[name] {
+		return true, nil
+	}
+	return false, ErrUnknownFeature
+}
+
+// containsString reports whether list holds s
+func containsString(list *[]string, s string) bool {
+	if list == nil {
+		return false
+	}
+	for _, v := range *list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Health checks that Keycloak is reachable and serves the configured
+// realm by fetching the realm's public information, which needs no
+// credentials. A nil error means the realm is available; a realm that
+// does not exist is reported as an ErrorResponse with 404 Not Found.
+func (c *Client) Health(ctx context.Context) (*Response, error) {
+	path, err := c.realmPath()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, "GET", path, nil, headers{}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(ctx, req, nil)
+}
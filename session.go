@@ -0,0 +1,89 @@
+package keycloak
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Session holds the tokens of a user logged in with Authenticate. It is
+// safe for concurrent use.
+type Session struct {
+	client *Client
+
+	mu     sync.Mutex // Guards token and expiry
+	token  *OIDCToken
+	expiry time.Time
+}
+
+// Authenticate logs a user in with the password grant and returns their
+// session. The openid scope is requested so Keycloak also issues an ID
+// token.
+func (c *Client) Authenticate(
+	ctx context.Context,
+	username string,
+	password string,
+) (*Session, *Response, error) {
+	token, resp, err := c.Authentication.GetOIDCToken(ctx, &AccessGrantRequest{
+		GrantType: GrantPassword,
+		Scope:     openIDScope,
+		Username:  username,
+		Password:  password,
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	s := &Session{client: c}
+	s.set(token)
+
+	return s, resp, nil
+}
+
+// set stores token and the time its access token stops being valid
+func (s *Session) set(token *OIDCToken) {
+	s.token = token
+	s.expiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryDelta)
+}
+
+// Token returns the session's current tokens
+func (s *Session) Token() *OIDCToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+// AccessToken returns the session's current access token
+func (s *Session) AccessToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token.AccessToken
+}
+
+// Valid reports whether the access token has not expired. An invalid
+// session may still be renewed with Refresh until the refresh token
+// expires.
+func (s *Session) Valid() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Now().Before(s.expiry)
+}
+
+// Refresh exchanges the refresh token for new tokens. The refresh is not
+// retried since Keycloak may revoke a refresh token once it is used.
+func (s *Session) Refresh(ctx context.Context) (*Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, resp, err := s.client.Authentication.GetOIDCToken(ctx, &AccessGrantRequest{
+		GrantType:    GrantRefreshToken,
+		RefreshToken: s.token.RefreshToken,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	s.set(token)
+
+	return resp, nil
+}
@@ -0,0 +1,26 @@
+package keycloak
+
+import "time"
+
+// Timestamp represents the epoch milliseconds Keycloak uses for admin API
+// timestamps such as a session start. It is encoded as a JSON number.
+type Timestamp int64
+
+// Time returns the timestamp as a time.Time
+func (t Timestamp) Time() time.Time {
+	return time.Unix(0, int64(t)*int64(time.Millisecond))
+}
+
+// NewTimestamp returns the Timestamp of tm, truncated to milliseconds
+func NewTimestamp(tm time.Time) Timestamp {
+	return Timestamp(tm.UnixNano() / int64(time.Millisecond))
+}
+
+// millisTime returns the time of the epoch milliseconds ms, or the zero
+// time if ms is nil
+func millisTime(ms *int64) time.Time {
+	if ms == nil {
+		return time.Time{}
+	}
+	return Timestamp(*ms).Time()
+}
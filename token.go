@@ -0,0 +1,95 @@
+package keycloak
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// TokenClaims represents the claims of a Keycloak issued JWT
+type TokenClaims struct {
+	Audience          Audience               `json:"aud,omitempty"`
+	AuthorizedParty   string                 `json:"azp,omitempty"`
+	Email             string                 `json:"email,omitempty"`
+	EmailVerified     bool                   `json:"email_verified,omitempty"`
+	ExpiresAt         int64                  `json:"exp,omitempty"`
+	FamilyName        string                 `json:"family_name,omitempty"`
+	GivenName         string                 `json:"given_name,omitempty"`
+	ID                string                 `json:"jti,omitempty"`
+	IssuedAt          int64                  `json:"iat,omitempty"`
+	Issuer            string                 `json:"iss,omitempty"`
+	Name              string                 `json:"name,omitempty"`
+	NotBefore         int64                  `json:"nbf,omitempty"`
+	PreferredUsername string                 `json:"preferred_username,omitempty"`
+	RealmAccess       *RoleAccess            `json:"realm_access,omitempty"`
+	ResourceAccess    map[string]*RoleAccess `json:"resource_access,omitempty"`
+	Scope             string                 `json:"scope,omitempty"`
+	SessionState      string                 `json:"session_state,omitempty"`
+	Subject           string                 `json:"sub,omitempty"`
+	Type              string                 `json:"typ,omitempty"`
+}
+
+// RoleAccess represents the roles granted in a realm or client
+type RoleAccess struct {
+	Roles []string `json:"roles"`
+}
+
+// Audience represents the aud claim, which is either a single string or
+// an array of strings
+type Audience []string
+
+// UnmarshalJSON accepts both forms of the aud claim
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// DecodeToken decodes the claims of a raw JWT. The signature is NOT
+// verified, so the claims must only be trusted if the token was obtained
+// directly from Keycloak.
+func DecodeToken(raw string) (*TokenClaims, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("keycloak: token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, err
+	}
+
+	claims := new(TokenClaims)
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// RealmRoles returns the realm roles granted by the token
+func (t *TokenClaims) RealmRoles() []string {
+	if t.RealmAccess == nil {
+		return nil
+	}
+	return t.RealmAccess.Roles
+}
+
+// ClientRoles returns the roles of the client with the given clientId
+// granted by the token
+func (t *TokenClaims) ClientRoles(clientID string) []string {
+	if access, ok := t.ResourceAccess[clientID]; ok && access != nil {
+		return access.Roles
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package keycloak
+
+import "net/http"
+
+// TokenRoundTripper is an http.RoundTripper that adds the Client's access
+// token as a bearer Authorization header to every request, obtaining and
+// renewing the token as the Client's admin requests do. It turns a
+// service account Client into an authenticated http.Client for calling
+// downstream APIs:
+//
+//	httpClient := &http.Client{Transport: keycloak.NewTokenRoundTripper(kc, nil)}
+type TokenRoundTripper struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+// NewTokenRoundTripper wraps base, or http.DefaultTransport if base is nil
+func NewTokenRoundTripper(c *Client, base http.RoundTripper) *TokenRoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &TokenRoundTripper{client: c, base: base}
+}
+
+// RoundTrip authenticates and sends the request. The original request is
+// not modified.
+func (t *TokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.client.adminToken(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	authReq := req.Clone(req.Context())
+	authReq.Header.Set("Authorization", "Bearer "+token)
+
+	return t.base.RoundTrip(authReq)
+}
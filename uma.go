@@ -2,12 +2,45 @@ package keycloak
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
 )
 
 // UMAService handles communication with Keycloak UMA
 type UMAService service
 
+// GetPAT returns the protection API token (PAT) of the configured client,
+// granting a new one with client_credentials when none is cached or the
+// cached one is about to expire. The client needs the uma_protection role.
+// The PAT is cached separately from the admin token. No method of this
+// package uses it: it is for callers making their own protection API
+// requests, e.g. to manage resources or permission tickets, with its
+// AuthorizationHeader.
+func (c *UMAService) GetPAT(ctx context.Context) (*OIDCToken, error) {
+	c.client.patMu.Lock()
+	defer c.client.patMu.Unlock()
+
+	if c.client.pat != nil && time.Now().Before(c.client.patExpiry) {
+		return c.client.pat, nil
+	}
+
+	// Repeating a grant has no side effects so it is always retryable
+	token, _, err := c.client.Authentication.GetOIDCToken(WithRetryablePOST(ctx), &AccessGrantRequest{
+		GrantType: GrantClientCredentials,
+		Scope:     c.client.grantScope(""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.client.pat = token
+	c.client.patExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryDelta)
+
+	return token, nil
+}
+
 // GetUMAUser allows user to view their token mappings.
 // The provided interface is returned to be decoded on success.
 func (c *UMAService) GetUMAUser(
@@ -15,10 +48,13 @@ func (c *UMAService) GetUMAUser(
 	token string,
 	v interface{},
 ) (interface{}, *Response, error) {
-	path := fmt.Sprintf("%s/%s/protocol/openid-connect/userinfo", defaultBase, c.client.realm)
+	path, err := c.client.realmPath("protocol", "openid-connect", "userinfo")
+	if err != nil {
+		return nil, nil, err
+	}
 	h := headers{authorization: token}
 
-	req, err := c.client.newRequest("GET", path, nil, h, false)
+	req, err := c.client.newRequest(ctx, "GET", path, nil, h, false)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -30,3 +66,72 @@ func (c *UMAService) GetUMAUser(
 
 	return v, resp, nil
 }
+
+// UMAChallenge represents the UMA WWW-Authenticate challenge a resource
+// server returns with 401 Unauthorized for a request without an RPT
+type UMAChallenge struct {
+	Realm  string
+	ASURI  string // the authorization server to request an RPT from
+	Ticket string // the permission ticket to exchange for an RPT
+}
+
+// ErrNoUMAChallenge is returned by ParseUMAChallenge when the response
+// has no UMA challenge
+var ErrNoUMAChallenge = errors.New("keycloak: response has no UMA challenge")
+
+// ParseUMAChallenge extracts the UMA challenge from the WWW-Authenticate
+// headers of a resource server response
+func ParseUMAChallenge(resp *http.Response) (*UMAChallenge, error) {
+	for _, header := range resp.Header.Values("WWW-Authenticate") {
+		scheme, params, _ := strings.Cut(strings.TrimSpace(header), " ")
+		if !strings.EqualFold(scheme, "UMA") {
+			continue
+		}
+
+		challenge := new(UMAChallenge)
+		for name, value := range parseAuthParams(params) {
+			switch strings.ToLower(name) {
+			case "realm":
+				challenge.Realm = value
+			case "as_uri":
+				challenge.ASURI = value
+			case "ticket":
+				challenge.Ticket = value
+			}
+		}
+		if challenge.Ticket == "" {
+			return nil, errors.New("keycloak: UMA challenge has no ticket")
+		}
+		return challenge, nil
+	}
+
+	return nil, ErrNoUMAChallenge
+}
+
+// parseAuthParams parses comma separated name=value auth parameters
+// whose values may be quoted strings containing commas
+func parseAuthParams(s string) map[string]string {
+	params := make(map[string]string)
+	for {
+		s = strings.TrimLeft(s, " ,")
+		name, rest, ok := strings.Cut(s, "=")
+		if !ok {
+			return params
+		}
+		name = strings.TrimSpace(name)
+		rest = strings.TrimLeft(rest, " ")
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				return params
+			}
+			value, s = rest[1:end+1], rest[end+2:]
+		} else {
+			value, s, _ = strings.Cut(rest, ",")
+			value = strings.TrimSpace(value)
+		}
+		params[name] = value
+	}
+}
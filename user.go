@@ -2,12 +2,22 @@ package keycloak
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
 )
 
 // AdminUserService handles communication with keycloak user management
 type AdminUserService service
 
+// ErrUserNotFound is returned when a lookup matches no user
+var ErrUserNotFound = errors.New("keycloak: user not found")
+
 // User represents the Keycloak user
 type User struct {
 	Access                     *map[string]interface{} `json:"access,omitempty"`
@@ -35,6 +45,54 @@ type User struct {
 	Username                   *string                 `json:"username,omitempty"`
 }
 
+// Created returns CreatedTimestamp as a time.Time, or the zero time if
+// it is unset
+func (u *User) Created() time.Time {
+	return millisTime(u.CreatedTimestamp)
+}
+
+// GetAttribute returns the values of a user attribute, or nil if the
+// attribute is not set
+func (u *User) GetAttribute(key string) []string {
+	if u.Attributes == nil {
+		return nil
+	}
+
+	switch values := (*u.Attributes)[key].(type) {
+	case []string:
+		return values
+	case []interface{}: // decoded from JSON
+		attr := make([]string, 0, len(values))
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				attr = append(attr, s)
+			}
+		}
+		return attr
+	case string:
+		return []string{values}
+	}
+	return nil
+}
+
+// GetFirstAttribute returns the first value of a user attribute, or an
+// empty string if the attribute is not set
+func (u *User) GetFirstAttribute(key string) string {
+	if values := u.GetAttribute(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// SetAttribute replaces the values of a user attribute, creating the
+// attributes map if needed
+func (u *User) SetAttribute(key string, values ...string) {
+	if u.Attributes == nil || *u.Attributes == nil {
+		u.Attributes = &map[string]interface{}{}
+	}
+	(*u.Attributes)[key] = values
+}
+
 // FederatedIdentity represents third party signups
 type FederatedIdentity struct {
 	IdentityProvider *string `json:"identityProvider,omitempty"`
@@ -52,23 +110,89 @@ type UserConsent struct {
 	LastUpdatedDate        *int64                  `json:"lastUpdatedDate,omitempty"`
 }
 
+// Created returns CreatedDate as a time.Time, or the zero time if it is
+// unset
+func (c *UserConsent) Created() time.Time {
+	return millisTime(c.CreatedDate)
+}
+
+// LastUpdated returns LastUpdatedDate as a time.Time, or the zero time if
+// it is unset
+func (c *UserConsent) LastUpdated() time.Time {
+	return millisTime(c.LastUpdatedDate)
+}
+
 // Credential represents the user's credentials type
 type Credential struct {
 	Algorithm         *string             `json:"algorithm,omitempty"`
 	Config            *MultivaluedHashMap `json:"config,omitempty"`
 	Counter           *int32              `json:"counter,omitempty"`
 	CreatedDate       *int64              `json:"createdDate,omitempty"`
+	CredentialData    *string             `json:"credentialData,omitempty"` // JSON encoded, see PasswordHash
 	Device            *string             `json:"device,omitempty"`
 	Digits            *int32              `json:"digits,omitempty"`
 	HashIterations    *int32              `json:"hashIterations,omitempty"`
 	HashedSaltedValue *string             `json:"hashedSaltedValue,omitempty"`
+	ID                *string             `json:"id,omitempty"`
 	Period            *int32              `json:"period,omitempty"`
+	Priority          *int32              `json:"priority,omitempty"`
 	Salt              *string             `json:"salt,omitempty"`
+	SecretData        *string             `json:"secretData,omitempty"` // JSON encoded, see PasswordHash
 	Temporary         *bool               `json:"temporary,omitempty"`
 	Type              *string             `json:"type,omitempty"`
+	UserLabel         *string             `json:"userLabel,omitempty"`
 	Value             *string             `json:"value,omitempty"`
 }
 
+// Created returns CreatedDate as a time.Time, or the zero time if it is
+// unset
+func (c *Credential) Created() time.Time {
+	return millisTime(c.CreatedDate)
+}
+
+// Credential types of WebAuthn authenticators
+const (
+	CredentialWebAuthn             = "webauthn"
+	CredentialWebAuthnPasswordless = "webauthn-passwordless"
+)
+
+// WebAuthnCredentialData represents the credentialData of a WebAuthn
+// credential. AAGUID identifies the authenticator model.
+type WebAuthnCredentialData struct {
+	AAGUID                     string   `json:"aaguid"`
+	AttestationStatementFormat string   `json:"attestationStatementFormat"`
+	CredentialID               string   `json:"credentialId"`
+	Counter                    int64    `json:"counter"`
+	Transports                 []string `json:"transports"`
+}
+
+// IsWebAuthn reports whether the credential is a WebAuthn credential, such
+// as a security key or passkey
+func (c *Credential) IsWebAuthn() bool {
+	if c.Type == nil {
+		return false
+	}
+	return *c.Type == CredentialWebAuthn || *c.Type == CredentialWebAuthnPasswordless
+}
+
+// WebAuthnData decodes the credentialData of a WebAuthn credential. The
+// user's label for the authenticator is the credential's UserLabel.
+func (c *Credential) WebAuthnData() (*WebAuthnCredentialData, error) {
+	if !c.IsWebAuthn() {
+		return nil, errors.New("keycloak: credential is not a WebAuthn credential")
+	}
+	if c.CredentialData == nil {
+		return nil, errors.New("keycloak: credential has no credentialData")
+	}
+
+	data := new(WebAuthnCredentialData)
+	if err := json.Unmarshal([]byte(*c.CredentialData), data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
 // MultivaluedHashMap ...
 type MultivaluedHashMap struct {
 	Empty      *bool  `json:"empty,omitempty"`
@@ -76,14 +200,120 @@ type MultivaluedHashMap struct {
 	Threshold  *int32 `json:"threshold,omitempty"`
 }
 
+// UserSession represents an active or offline user session
+type UserSession struct {
+	Clients    *map[string]string `json:"clients,omitempty"`
+	ID         *string            `json:"id,omitempty"`
+	IPAddress  *string            `json:"ipAddress,omitempty"`
+	LastAccess *Timestamp         `json:"lastAccess,omitempty"`
+	RememberMe *bool              `json:"rememberMe,omitempty"`
+	Start      *Timestamp         `json:"start,omitempty"`
+	UserID     *string            `json:"userId,omitempty"`
+	Username   *string            `json:"username,omitempty"`
+}
+
+// GetUsersParams represents the optional search parameters of GetUsers
+type GetUsersParams struct {
+	BriefRepresentation *bool  `url:"briefRepresentation,omitempty"`
+	Email               string `url:"email,omitempty"`
+	Enabled             *bool  `url:"enabled,omitempty"`
+	Exact               *bool  `url:"exact,omitempty"` // exact matches, including Attributes
+	First               int    `url:"first,omitempty"`
+	FirstName           string `url:"firstName,omitempty"`
+	LastName            string `url:"lastName,omitempty"`
+	Max                 int    `url:"max,omitempty"`
+	Search              string `url:"search,omitempty"`
+	Username            string `url:"username,omitempty"`
+
+	// Attributes matches users whose custom attributes have the given
+	// values, e.g. {"employeeId": "1234"}. Some Keycloak versions match
+	// attribute values as substrings unless Exact is set.
+	Attributes AttributeQuery `url:"q,omitempty"`
+}
+
+// AttributeQuery matches users by custom attribute values. Keycloak
+// separates terms by spaces so keys and values cannot contain them.
+type AttributeQuery map[string]string
+
+// EncodeValues encodes the attributes as a Keycloak key:value search query
+func (q AttributeQuery) EncodeValues(key string, v *url.Values) error {
+	terms := make([]string, 0, len(q))
+	for attr, value := range q {
+		terms = append(terms, attr+":"+value)
+	}
+	sort.Strings(terms)
+
+	v.Set(key, strings.Join(terms, " "))
+	return nil
+}
+
+// GetUsers retrieves the users matching params
+func (c *AdminUserService) GetUsers(
+	ctx context.Context,
+	params *GetUsersParams,
+) ([]*User, *Response, error) {
+	path, err := c.client.adminPath(ctx, "users")
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var users []*User
+	resp, err := c.client.do(ctx, req, &users)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return users, resp, nil
+}
+
+// StreamUsers writes the raw JSON array of the users matching params to w
+// without decoding it, which keeps memory use flat for large exports. An
+// error is returned if the body is cut off, in which case w holds
+// truncated JSON. The default HTTP client's 30 second timeout includes
+// reading the body, so pass an httpClient without a Timeout and bound
+// the export with ctx instead for large realms.
+func (c *AdminUserService) StreamUsers(
+	ctx context.Context,
+	params *GetUsersParams,
+	w io.Writer,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users")
+	if err != nil {
+		return nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, w)
+}
+
 // GetUserByID retrieves a user by ID
 func (c *AdminUserService) GetUserByID(
 	ctx context.Context,
 	ID string,
 ) (*User, *Response, error) {
-	path := fmt.Sprintf("%s/%s/users/%s", defaultAdminBase, c.client.realm, ID)
+	path, err := c.client.adminPath(ctx, "users", ID)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	req, err := c.client.newRequest("GET", path, nil, headers{}, true)
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -96,3 +326,738 @@ func (c *AdminUserService) GetUserByID(
 
 	return user, resp, nil
 }
+
+// GetUserByEmail retrieves the user with the given email address. Emails
+// are matched exactly; ErrUserNotFound is returned when none matches and
+// an error when, with duplicate emails allowed in the realm, several do.
+func (c *AdminUserService) GetUserByEmail(
+	ctx context.Context,
+	email string,
+) (*User, *Response, error) {
+	users, resp, err := c.GetUsers(ctx, &GetUsersParams{
+		Email: email,
+		Exact: Bool(true),
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	switch len(users) {
+	case 0:
+		return nil, resp, ErrUserNotFound
+	case 1:
+		return users[0], resp, nil
+	}
+
+	return nil, resp, fmt.Errorf("keycloak: %d users match email %q", len(users), email)
+}
+
+// CreateUser creates a new user
+func (c *AdminUserService) CreateUser(
+	ctx context.Context,
+	user *User,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, user, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// accountStateErrors are the invalid_grant descriptions Keycloak gives
+// for a password grant refused because of the account rather than the
+// password
+var accountStateErrors = map[string]bool{
+	"Account disabled":             true,
+	"Account temporarily disabled": true,
+	"Account is not fully set up":  true,
+}
+
+// VerifyPassword reports whether password is the user's current password
+// by attempting a password grant with the configured client, which must
+// allow direct access grants. The session the grant opens is logged out
+// again; if that fails, true is returned with the error. A failed verification counts towards brute force detection like
+// a failed login.
+//
+// Only an invalid_grant rejection of the credentials reports false. A
+// disabled account, or one with pending required actions, is returned
+// as an error even when the password is right, as is any other failure
+// such as a misconfigured client. Keycloak may hide that an account is
+// locked by brute force detection, in which case false is reported.
+func (c *AdminUserService) VerifyPassword(
+	ctx context.Context,
+	username string,
+	password string,
+) (bool, *Response, error) {
+	token, resp, err := c.client.Authentication.GetOIDCToken(ctx, &AccessGrantRequest{
+		GrantType: GrantPassword,
+		Username:  username,
+		Password:  password,
+	})
+	if err != nil {
+		if errResp, ok := err.(*ErrorResponse); ok && errResp.ErrorCode == "invalid_grant" &&
+			!accountStateErrors[errResp.Message] {
+			return false, &Response{Response: errResp.Response}, nil
+		}
+		return false, resp, err
+	}
+
+	if token.RefreshToken != "" {
+		if resp, err := c.client.Authentication.Logout(ctx, token.RefreshToken); err != nil {
+			return true, resp, err
+		}
+	}
+
+	return true, resp, nil
+}
+
+// PasswordHash represents a password hashed outside of Keycloak. Value
+// and Salt are base64 encoded. Algorithm must name a password hashing
+// provider installed in Keycloak, e.g. pbkdf2-sha256.
+type PasswordHash struct {
+	Algorithm  string
+	Iterations int
+	Salt       string
+	Value      string
+}
+
+// credential returns the hash as a password credential in Keycloak's
+// credentialData and secretData format
+func (h *PasswordHash) credential() (Credential, error) {
+	credentialData, err := json.Marshal(struct {
+		Algorithm            string            `json:"algorithm"`
+		HashIterations       int               `json:"hashIterations"`
+		AdditionalParameters map[string]string `json:"additionalParameters"`
+	}{h.Algorithm, h.Iterations, map[string]string{}})
+	if err != nil {
+		return Credential{}, err
+	}
+
+	secretData, err := json.Marshal(struct {
+		Value                string            `json:"value"`
+		Salt                 string            `json:"salt"`
+		AdditionalParameters map[string]string `json:"additionalParameters"`
+	}{h.Value, h.Salt, map[string]string{}})
+	if err != nil {
+		return Credential{}, err
+	}
+
+	return Credential{
+		Type:           String("password"),
+		CredentialData: String(string(credentialData)),
+		SecretData:     String(string(secretData)),
+	}, nil
+}
+
+// CreateUserWithHashedPassword creates a new user whose password is the
+// given pre-hashed password, which allows migrating users from other
+// systems without resetting their passwords. Credentials already set on
+// user are replaced; user itself is not modified.
+func (c *AdminUserService) CreateUserWithHashedPassword(
+	ctx context.Context,
+	user *User,
+	hash *PasswordHash,
+) (*Response, error) {
+	credential, err := hash.credential()
+	if err != nil {
+		return nil, err
+	}
+
+	withPassword := *user
+	withPassword.Credentials = &[]Credential{credential}
+
+	return c.CreateUser(ctx, &withPassword)
+}
+
+// UpdateUser replaces a user's representation. Fields left nil are kept.
+func (c *AdminUserService) UpdateUser(
+	ctx context.Context,
+	ID string,
+	user *User,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users", ID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "PUT", path, user, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// SetEmailVerified sets whether a user's email address is verified,
+// leaving the user's other fields untouched
+func (c *AdminUserService) SetEmailVerified(
+	ctx context.Context,
+	userID string,
+	verified bool,
+) (*Response, error) {
+	return c.UpdateUser(ctx, userID, &User{EmailVerified: Bool(verified)})
+}
+
+// UpdateUserFunc reads a user, applies mutate, and writes the result back.
+// Keycloak has no conditional updates, so this is an unguarded
+// read-modify-write: a change made by someone else between the read and
+// the write is silently overwritten. An error from mutate aborts the
+// update.
+func (c *AdminUserService) UpdateUserFunc(
+	ctx context.Context,
+	userID string,
+	mutate func(*User) error,
+) (*Response, error) {
+	user, resp, err := c.GetUserByID(ctx, userID)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := mutate(user); err != nil {
+		return resp, err
+	}
+
+	return c.UpdateUser(ctx, userID, user)
+}
+
+// DeleteUser deletes a user
+func (c *AdminUserService) DeleteUser(
+	ctx context.Context,
+	ID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users", ID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// DeleteUsersError maps the IDs of users that could not be deleted to the
+// error returned for each
+type DeleteUsersError map[string]error
+
+func (e DeleteUsersError) Error() string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	failures := make([]string, len(ids))
+	for i, id := range ids {
+		failures[i] = fmt.Sprintf("%s: %v", id, e[id])
+	}
+	return fmt.Sprintf("keycloak: failed to delete %d user(s): %s", len(e), strings.Join(failures, "; "))
+}
+
+// DeleteUsers deletes each of the users, continuing past failures. If any
+// deletion fails a DeleteUsersError describing every failure is returned.
+// If ctx is done it stops and returns the context's error, leaving the
+// remaining users in place.
+func (c *AdminUserService) DeleteUsers(
+	ctx context.Context,
+	userIDs []string,
+) error {
+	failed := DeleteUsersError{}
+	for _, id := range userIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := c.DeleteUser(ctx, id); err != nil {
+			failed[id] = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return failed
+	}
+	return nil
+}
+
+// GetUserCredentials retrieves the credentials of a user in priority order.
+// If types are given, e.g. "otp" or "webauthn", only credentials of those
+// types are returned. Keycloak has no server-side filter so the full list
+// is always fetched.
+func (c *AdminUserService) GetUserCredentials(
+	ctx context.Context,
+	userID string,
+	types ...string,
+) ([]*Credential, *Response, error) {
+	path, err := c.client.adminPath(ctx, "users", userID, "credentials")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var credentials []*Credential
+	resp, err := c.client.do(ctx, req, &credentials)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(types) == 0 {
+		return credentials, resp, nil
+	}
+
+	filtered := make([]*Credential, 0, len(credentials))
+	for _, credential := range credentials {
+		for _, t := range types {
+			if credential.Type != nil && *credential.Type == t {
+				filtered = append(filtered, credential)
+				break
+			}
+		}
+	}
+
+	return filtered, resp, nil
+}
+
+// ResetPassword sets a user's password. A temporary password must be
+// changed by the user on their next login. Keycloak replaces the user's
+// password credential and cannot target one of several by ID; remove any
+// extra password credentials with DeleteCredential first.
+func (c *AdminUserService) ResetPassword(
+	ctx context.Context,
+	userID string,
+	password string,
+	temporary bool,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users", userID, "reset-password")
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &Credential{
+		Type:      String("password"),
+		Value:     String(password),
+		Temporary: Bool(temporary),
+	}
+
+	req, err := c.client.newRequest(ctx, "PUT", path, credential, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// DeleteCredential removes one of a user's credentials by ID
+func (c *AdminUserService) DeleteCredential(
+	ctx context.Context,
+	userID string,
+	credentialID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users", userID, "credentials", credentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// MoveCredentialToFirst gives a user's credential the highest priority
+func (c *AdminUserService) MoveCredentialToFirst(
+	ctx context.Context,
+	userID string,
+	credentialID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users", userID, "credentials", credentialID, "moveToFirst")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// MoveCredentialAfter places a user's credential directly after
+// newPreviousCredentialID in priority order
+func (c *AdminUserService) MoveCredentialAfter(
+	ctx context.Context,
+	userID string,
+	credentialID string,
+	newPreviousCredentialID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users", userID, "credentials", credentialID, "moveAfter", newPreviousCredentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// DisableCredentialTypes disables all of a user's credentials of the
+// given types, e.g. otp. The types a user can disable are listed in the
+// user's DisableableCredentialTypes.
+func (c *AdminUserService) DisableCredentialTypes(
+	ctx context.Context,
+	userID string,
+	types []string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users", userID, "disable-credential-types")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "PUT", path, types, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// BatchError reports which batch of a chunked operation failed.
+// Every batch before Batch was applied successfully.
+type BatchError struct {
+	Batch int     // zero-based index of the failed batch
+	Roles []*Role // roles in the failed batch
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("keycloak: batch %d failed: %v", e.Batch, e.Err)
+}
+
+// Unwrap returns the error that caused the batch to fail
+func (e *BatchError) Unwrap() error { return e.Err }
+
+// AddRealmRoles assigns realm roles to a user
+func (c *AdminUserService) AddRealmRoles(
+	ctx context.Context,
+	userID string,
+	roles []*Role,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users", userID, "role-mappings", "realm")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, roles, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// AddRealmRolesInBatches assigns realm roles to a user in sequential
+// batches of at most batchSize roles to keep request bodies small.
+// Assignment stops at the first failing batch and a *BatchError is
+// returned; earlier batches remain assigned. A batchSize of zero or less
+// assigns every role in a single request.
+func (c *AdminUserService) AddRealmRolesInBatches(
+	ctx context.Context,
+	userID string,
+	roles []*Role,
+	batchSize int,
+) (*Response, error) {
+	if batchSize <= 0 {
+		batchSize = len(roles)
+	}
+
+	var resp *Response
+	for batch, start := 0, 0; start < len(roles); batch, start = batch+1, start+batchSize {
+		end := start + batchSize
+		if end > len(roles) {
+			end = len(roles)
+		}
+
+		var err error
+		resp, err = c.AddRealmRoles(ctx, userID, roles[start:end])
+		if err != nil {
+			return resp, &BatchError{Batch: batch, Roles: roles[start:end], Err: err}
+		}
+	}
+
+	return resp, nil
+}
+
+// GetOfflineSessions retrieves a user's offline sessions for the client
+// with the given internal client UUID
+func (c *AdminUserService) GetOfflineSessions(
+	ctx context.Context,
+	userID string,
+	clientUUID string,
+) ([]*UserSession, *Response, error) {
+	path, err := c.client.adminPath(ctx, "users", userID, "offline-sessions", clientUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sessions []*UserSession
+	resp, err := c.client.do(ctx, req, &sessions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sessions, resp, nil
+}
+
+// ActionEmailParams represents the optional query parameters of the
+// action email endpoints
+type ActionEmailParams struct {
+	// ClientID selects the client whose login theme brands the pages the
+	// link opens. It is required when RedirectURI is set.
+	ClientID string `url:"client_id,omitempty"`
+	// RedirectURI is where the user is sent once the actions are done. It
+	// must be a valid redirect URI of the client.
+	RedirectURI string `url:"redirect_uri,omitempty"`
+	Lifespan    int    `url:"lifespan,omitempty"` // link lifespan in seconds
+
+	// Locale selects the language the email is rendered in when realm
+	// internationalization is on. Keycloak renders admin emails in the
+	// user's locale, so it is stored as the user's locale attribute
+	// before the email is sent and stays set afterwards.
+	Locale string `url:"-"`
+}
+
+// validate checks the parameters Keycloak would reject together
+func (p *ActionEmailParams) validate() error {
+	if p != nil && p.RedirectURI != "" && p.ClientID == "" {
+		return errors.New("keycloak: redirect_uri requires client_id")
+	}
+	return nil
+}
+
+// applyEmailLocale stores the locale of params, if any, as the user's
+// locale attribute
+func (c *AdminUserService) applyEmailLocale(
+	ctx context.Context,
+	userID string,
+	params *ActionEmailParams,
+) (*Response, error) {
+	if params == nil || params.Locale == "" {
+		return nil, nil
+	}
+
+	return c.UpdateUserFunc(ctx, userID, func(user *User) error {
+		user.SetAttribute("locale", params.Locale)
+		return nil
+	})
+}
+
+// DeleteUserSession terminates a single user session, leaving the user's
+// other sessions intact
+func (c *AdminUserService) DeleteUserSession(
+	ctx context.Context,
+	sessionID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "sessions", sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// ExecuteActionsEmail sends the user an email with a link to perform the
+// given required actions, e.g. UPDATE_PASSWORD or VERIFY_EMAIL
+func (c *AdminUserService) ExecuteActionsEmail(
+	ctx context.Context,
+	userID string,
+	actions []string,
+	params *ActionEmailParams,
+) (*Response, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	path, err := c.client.adminPath(ctx, "users", userID, "execute-actions-email")
+	if err != nil {
+		return nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp, err := c.applyEmailLocale(ctx, userID, params); err != nil {
+		return resp, err
+	}
+
+	// Repeating the request would send the email again
+	ctx = withoutRetry(ctx)
+	req, err := c.client.newRequest(ctx, "PUT", path, actions, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// SendVerifyEmail sends the user an email with a link to verify their
+// email address
+func (c *AdminUserService) SendVerifyEmail(
+	ctx context.Context,
+	userID string,
+	params *ActionEmailParams,
+) (*Response, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	path, err := c.client.adminPath(ctx, "users", userID, "send-verify-email")
+	if err != nil {
+		return nil, err
+	}
+	path, err = addParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp, err := c.applyEmailLocale(ctx, userID, params); err != nil {
+		return resp, err
+	}
+
+	// Repeating the request would send the email again
+	ctx = withoutRetry(ctx)
+	req, err := c.client.newRequest(ctx, "PUT", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// LinkIdentityProvider links a user to their account at an identity
+// provider, identified by the provider's user ID and username. Once
+// linked the user can log in through the provider without Keycloak
+// prompting them to link accounts, which suits migrations of users that
+// already exist on both sides.
+func (c *AdminUserService) LinkIdentityProvider(
+	ctx context.Context,
+	userID string,
+	providerAlias string,
+	externalUserID string,
+	externalUsername string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users", userID, "federated-identity", providerAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	link := &FederatedIdentity{
+		IdentityProvider: &providerAlias,
+		UserID:           &externalUserID,
+		UserName:         &externalUsername,
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, link, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// BruteForceStatus represents a user's brute force detection state
+type BruteForceStatus struct {
+	Disabled      *bool      `json:"disabled,omitempty"`
+	LastFailure   *Timestamp `json:"lastFailure,omitempty"`
+	LastIPFailure *string    `json:"lastIPFailure,omitempty"`
+	NumFailures   *int32     `json:"numFailures,omitempty"`
+}
+
+// GetBruteForceStatus retrieves the brute force detection state of a user
+func (c *AdminUserService) GetBruteForceStatus(
+	ctx context.Context,
+	userID string,
+) (*BruteForceStatus, *Response, error) {
+	path, err := c.client.adminPath(ctx, "attack-detection", "brute-force", "users", userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "GET", path, nil, headers{}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := new(BruteForceStatus)
+	resp, err := c.client.do(ctx, req, status)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return status, resp, nil
+}
+
+// ClearBruteForce clears the login failures of a user, unlocking them if
+// they were temporarily disabled
+func (c *AdminUserService) ClearBruteForce(
+	ctx context.Context,
+	userID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "attack-detection", "brute-force", "users", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "DELETE", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
+
+// SetNotBefore sets a user's not-before timestamp to the current time so
+// every token issued to them until now is rejected. Keycloak only updates
+// a user's not-before through its user logout endpoint, so the user's
+// sessions are removed as well.
+func (c *AdminUserService) SetNotBefore(
+	ctx context.Context,
+	userID string,
+) (*Response, error) {
+	path, err := c.client.adminPath(ctx, "users", userID, "logout")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.newRequest(ctx, "POST", path, nil, headers{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.do(ctx, req, nil)
+}
@@ -0,0 +1,63 @@
+package keycloak
+
+// UserBuilder assembles a User ready to be passed to CreateUser
+type UserBuilder struct {
+	user *User
+}
+
+// NewUserBuilder starts a User with the given username. The user is
+// disabled until Enabled is called, matching Keycloak's default.
+func NewUserBuilder(username string) *UserBuilder {
+	return &UserBuilder{user: &User{Username: String(username)}}
+}
+
+// Email sets the user's email address
+func (b *UserBuilder) Email(email string) *UserBuilder {
+	b.user.Email = String(email)
+	return b
+}
+
+// EmailVerified marks the user's email address as verified
+func (b *UserBuilder) EmailVerified() *UserBuilder {
+	b.user.EmailVerified = Bool(true)
+	return b
+}
+
+// FirstName sets the user's first name
+func (b *UserBuilder) FirstName(firstName string) *UserBuilder {
+	b.user.FirstName = String(firstName)
+	return b
+}
+
+// LastName sets the user's last name
+func (b *UserBuilder) LastName(lastName string) *UserBuilder {
+	b.user.LastName = String(lastName)
+	return b
+}
+
+// Password sets the user's password. A temporary password must be changed
+// by the user on their next login.
+func (b *UserBuilder) Password(password string, temporary bool) *UserBuilder {
+	credentials := []Credential{{
+		Type:      String("password"),
+		Value:     String(password),
+		Temporary: Bool(temporary),
+	}}
+	b.user.Credentials = &credentials
+	return b
+}
+
+// Enabled allows the user to log in
+func (b *UserBuilder) Enabled() *UserBuilder {
+	b.user.Enabled = Bool(true)
+	return b
+}
+
+// Build returns the assembled User
+func (b *UserBuilder) Build() *User { return b.user }
+
+// String returns a pointer to the string value
+func String(v string) *string { return &v }
+
+// Bool returns a pointer to the bool value
+func Bool(v bool) *bool { return &v }